@@ -35,12 +35,9 @@ func main() {
 		StartTime: startTime,
 		SLALength: 4,
 		TimeUnit:  "hours", // SLA length of 4 hours
-		BusinessHours: struct {
-			StartHour int
-			EndHour   int
-		}{
-			StartHour: 9,
-			EndHour:   17,
+		BusinessHours: slachecker.BusinessHours{
+			Start: slachecker.NewTimeOfDay(9, 0),
+			End:   slachecker.NewTimeOfDay(17, 0),
 		},
 		ValidDays: validDays,
 	}
@@ -53,8 +50,11 @@ func main() {
 		sla.Holidays = fetchedHolidays
 	}
 
-	// Check SLA with current time
-	result := sla.CheckSLA(time.Now().UTC())
+	// Check SLA with the current time of the system clock
+	result, err := sla.EvaluateNow()
+	if err != nil {
+		log.Fatalf("Error evaluating SLA: %v", err)
+	}
 
 	// Marshal and print JSON result
 	jsonData, err := json.MarshalIndent(result, "", "  ")
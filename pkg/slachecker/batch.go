@@ -0,0 +1,141 @@
+package slachecker
+
+import (
+	"context"
+	"runtime"
+	"sync"
+	"time"
+)
+
+// BatchEvaluator evaluates many SLAs at once with a bounded pool of workers, e.g. every open ticket in a nightly job
+type BatchEvaluator struct {
+	Workers  int       // Number of SLAs evaluated at the same time, defaults to the number of CPUs
+	Calendar *Calendar // Shared calendar for every SLA without a Calendar of its own; their own calendar fields are then ignored
+	Clock    Clock     // Tells the current time to EvaluateNow, defaults to the system clock
+}
+
+// BatchResult is the result of one SLA of a batch
+type BatchResult struct {
+	Index  int       // Position of the SLA in the input
+	Result SLAResult // Zero when Err is set
+	Err    error     // Why the SLA could not be evaluated, e.g. a *ValidationError or the context's error
+}
+
+// batchJob is one SLA waiting for a worker, with the slot its result is delivered to
+type batchJob struct {
+	index  int
+	sla    SLA
+	result chan<- BatchResult
+}
+
+// Evaluate evaluates every SLA at currentTime and returns their results in input order, each with its own error.
+// Once ctx is done the SLAs not yet evaluated are given its error, which is also returned.
+func (b BatchEvaluator) Evaluate(ctx context.Context, slas []SLA, currentTime time.Time) ([]BatchResult, error) {
+	input := make(chan SLA)
+	go func() {
+		defer close(input)
+		for _, sla := range slas {
+			select {
+			case <-ctx.Done():
+				return
+			case input <- sla:
+			}
+		}
+	}()
+
+	results := make([]BatchResult, 0, len(slas))
+	stopped := false
+	for result := range b.EvaluateStream(ctx, input, currentTime) {
+		if result.Err != nil && result.Err == ctx.Err() {
+			stopped = true
+		}
+		results = append(results, result)
+	}
+
+	// Stopped early, so the remaining SLAs were never evaluated
+	for i := len(results); i < len(slas); i++ {
+		stopped = true
+		results = append(results, BatchResult{Index: i, Err: ctx.Err()})
+	}
+	if stopped {
+		return results, ctx.Err()
+	}
+	return results, nil
+}
+
+// EvaluateNow evaluates every SLA at the current time of the evaluator's Clock, read once for the whole batch
+func (b BatchEvaluator) EvaluateNow(ctx context.Context, slas []SLA) ([]BatchResult, error) {
+	return b.Evaluate(ctx, slas, now(b.Clock))
+}
+
+// EvaluateStream evaluates the SLAs received from slas at currentTime and sends their results in input order.
+// The results channel is closed once slas is closed and every result has been sent, or once ctx is done;
+// SLAs already received by then are given the context's error rather than being dropped. The results must be
+// read until the channel is closed.
+func (b BatchEvaluator) EvaluateStream(ctx context.Context, slas <-chan SLA, currentTime time.Time) <-chan BatchResult {
+	workers := b.Workers
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+
+	jobs := make(chan batchJob)
+	pending := make(chan chan BatchResult, workers) // Result slots in input order, bounding how far ahead workers run
+	results := make(chan BatchResult)
+
+	// Hand each SLA to a worker along with a slot for its result
+	go func() {
+		defer close(jobs)
+		defer close(pending)
+		for index := 0; ; index++ {
+			var sla SLA
+			select {
+			case <-ctx.Done():
+				return
+			case next, ok := <-slas:
+				if !ok {
+					return
+				}
+				sla = next
+			}
+
+			slot := make(chan BatchResult, 1)
+			pending <- slot
+			jobs <- batchJob{index: index, sla: sla, result: slot}
+		}
+	}()
+
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+			for job := range jobs {
+				job.result <- b.evaluate(ctx, job.index, job.sla, currentTime)
+			}
+		}()
+	}
+
+	// Deliver the results in input order as their slots are filled
+	go func() {
+		defer close(results)
+		for slot := range pending {
+			results <- <-slot
+		}
+		wg.Wait()
+	}()
+
+	return results
+}
+
+// evaluate evaluates one SLA of the batch, unless ctx is already done
+func (b BatchEvaluator) evaluate(ctx context.Context, index int, sla SLA, currentTime time.Time) BatchResult {
+	if err := ctx.Err(); err != nil {
+		return BatchResult{Index: index, Err: err}
+	}
+	if sla.Calendar == nil {
+		sla.Calendar = b.Calendar
+	}
+
+	result, err := sla.Evaluate(currentTime)
+	return BatchResult{Index: index, Result: result, Err: err}
+}
@@ -0,0 +1,119 @@
+package slachecker
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// setupBatch returns SLAs of 1 to n business hours starting Friday 4 PM, with every tenth one invalid
+func setupBatch(n int) []SLA {
+	slas := make([]SLA, n)
+	for i := range slas {
+		slas[i] = SLA{
+			StartTime: time.Date(2024, time.August, 23, 16, 0, 0, 0, time.UTC),
+			SLALength: i%8 + 1,
+			TimeUnit:  "hours",
+		}
+		if i%10 == 9 {
+			slas[i].TimeUnit = "fortnights"
+		}
+	}
+	return slas
+}
+
+func TestBatchEvaluate(t *testing.T) {
+	slas := setupBatch(100)
+	currentTime := time.Date(2024, time.August, 27, 12, 0, 0, 0, time.UTC)
+	evaluator := BatchEvaluator{Workers: 4, Calendar: setupCalendar()}
+
+	results, err := evaluator.Evaluate(context.Background(), slas, currentTime)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(results) != len(slas) {
+		t.Fatalf("Expected %d results, but got %d", len(slas), len(results))
+	}
+
+	for i, result := range results {
+		if result.Index != i {
+			t.Errorf("Expected result %d to have index %d, but got %d", i, i, result.Index)
+		}
+
+		sla := slas[i]
+		sla.Calendar = evaluator.Calendar
+		expected, expectedErr := sla.Evaluate(currentTime)
+		if expectedErr != nil {
+			if !errors.Is(result.Err, ErrInvalidTimeUnit) {
+				t.Errorf("%d: expected error %v, but got %v", i, expectedErr, result.Err)
+			}
+			continue
+		}
+		if result.Err != nil {
+			t.Fatalf("%d: unexpected error: %v", i, result.Err)
+		}
+		if !result.Result.Deadline.Equal(expected.Deadline) || result.Result.Status != expected.Status {
+			t.Errorf("%d: expected deadline %v and status %q, but got %v and %q", i, expected.Deadline, expected.Status, result.Result.Deadline, result.Result.Status)
+		}
+	}
+}
+
+func TestBatchEvaluateStream(t *testing.T) {
+	currentTime := time.Date(2024, time.August, 27, 12, 0, 0, 0, time.UTC)
+	evaluator := BatchEvaluator{Calendar: setupCalendar()}
+
+	slas := make(chan SLA)
+	go func() {
+		defer close(slas)
+		for _, sla := range setupBatch(50) {
+			slas <- sla
+		}
+	}()
+
+	count := 0
+	for result := range evaluator.EvaluateStream(context.Background(), slas, currentTime) {
+		if result.Index != count {
+			t.Errorf("Expected result %d to have index %d, but got %d", count, count, result.Index)
+		}
+		if invalid := count%10 == 9; invalid != (result.Err != nil) {
+			t.Errorf("%d: expected an error to be %v, but got %v", count, invalid, result.Err)
+		}
+		count++
+	}
+	if count != 50 {
+		t.Errorf("Expected 50 results, but got %d", count)
+	}
+}
+
+func TestBatchEvaluateCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	slas := setupBatch(20)
+	results, err := BatchEvaluator{Workers: 2, Calendar: setupCalendar()}.Evaluate(ctx, slas, time.Now())
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected error %v, but got %v", context.Canceled, err)
+	}
+	if len(results) != len(slas) {
+		t.Fatalf("Expected %d results, but got %d", len(slas), len(results))
+	}
+	for i, result := range results {
+		if result.Index != i || !errors.Is(result.Err, context.Canceled) {
+			t.Errorf("Expected result %d to be cancelled, but got index %d and error %v", i, result.Index, result.Err)
+		}
+	}
+}
+
+func BenchmarkBatchEvaluate(b *testing.B) {
+	slas := setupBatch(1000)
+	currentTime := time.Date(2024, time.August, 27, 12, 0, 0, 0, time.UTC)
+	evaluator := BatchEvaluator{Calendar: setupBenchmarkCalendar(false)}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := evaluator.Evaluate(context.Background(), slas, currentTime); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,482 @@
+package slachecker
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// BusinessCalendar answers questions about business time, independently of any SLA
+type BusinessCalendar interface {
+	// IsOpen reports whether t falls within business time
+	IsOpen(t time.Time) bool
+	// NextOpen returns t when it falls within business time, or the next opening time after it
+	NextOpen(t time.Time) (time.Time, error)
+	// AddBusinessTime returns the instant the given amount of business time after t, or before it when d is negative
+	AddBusinessTime(t time.Time, d time.Duration) (time.Time, error)
+	// BusinessTimeBetween returns the business time between two instants, negative when to is before from
+	BusinessTimeBetween(from, to time.Time) (time.Duration, error)
+}
+
+// Calendar holds the business hours, valid days and holidays business time is measured on.
+// A single Calendar can back any number of SLAs through SLA.Calendar, and be used on its own as a BusinessCalendar.
+// It compiles its business intervals one year at a time on first use, so deadlines and business durations
+// spanning months or years are found without walking every day, and validates itself once; a Calendar must
+// not be modified after its first use, and is safe for concurrent use.
+type Calendar struct {
+	BusinessHours   BusinessHours   // Daily opening and closing times, e.g. 08:30-17:45
+	BusinessWindows []BusinessHours // Several non-overlapping windows per day, e.g. 09:00-12:00 and 13:00-17:30; overrides BusinessHours when set
+	Schedule        WeeklySchedule  // Business windows per weekday; when set, ValidDays, BusinessHours and BusinessWindows are ignored
+	ValidDays       []time.Weekday  // e.g., []time.Weekday{time.Monday, time.Tuesday, ...}
+	Holidays        []time.Time     // Specific holidays when the business is closed
+	ReducedHours    []DayHours      // Business windows replacing the usual ones on specific dates, e.g. half days, even on holidays; ignored along with Holidays
+	ExtraHours      []DayHours      // Extra business windows on specific dates, e.g. a make-up Saturday; open even on holidays
+	Closures        []Closure       // Periods when the business is closed whatever the schedule says, e.g. an office move
+	IgnoreHolidays  bool            // Should holidays be taking into account
+	Location        *time.Location  // Time zone business hours, valid days and holidays are interpreted in, defaults to UTC
+	CountryCode     string          // ISO 3166-1 alpha-2 code of the country the holidays are for, e.g. "GB"; informational only
+
+	singleUse      bool           // Built for a single evaluation, so walked day by day rather than indexed
+	validationOnce sync.Once      // Guards hoursErr and datesErr
+	hoursErr       error          // Result of validateHours, checked once as the calendar must not change after first use
+	datesErr       error          // Result of validateDates, checked once for the same reason
+	datesOnce      sync.Once      // Guards dates
+	dates          *calendarDates // Weekly windows and dated entries compiled for constant-time lookups
+	mu             sync.RWMutex   // Guards years
+	years          map[int]*yearIndex
+}
+
+var _ BusinessCalendar = (*Calendar)(nil)
+
+// Validate checks the calendar is open on at least one day and every window, holiday and closure is valid.
+// Failures are reported as a *ValidationError wrapping one of the Err sentinel errors.
+func (c *Calendar) Validate() error {
+	if err := c.validateHours(); err != nil {
+		return err
+	}
+	return c.validateDates()
+}
+
+// validated returns the results of validateHours and validateDates, computed on first use so that
+// queries on a calendar in use cost no more as its holidays, dated hours and closures grow
+func (c *Calendar) validated() (hoursErr, datesErr error) {
+	c.validationOnce.Do(func() {
+		c.hoursErr = c.validateHours()
+		c.datesErr = c.validateDates()
+	})
+	return c.hoursErr, c.datesErr
+}
+
+// valid returns the first error from validated, as Validate would
+func (c *Calendar) valid() error {
+	hoursErr, datesErr := c.validated()
+	if hoursErr != nil {
+		return hoursErr
+	}
+	return datesErr
+}
+
+// validateHours checks the weekly business hours and valid days
+func (c *Calendar) validateHours() error {
+	if c.Schedule != nil {
+		// Validate the weekly schedule, which replaces ValidDays and BusinessHours
+		if err := c.Schedule.validate(); err != nil {
+			return withField("Schedule", err)
+		}
+		return nil
+	}
+
+	// Validate BusinessHours, or each of the BusinessWindows when they are set
+	windowsField := "BusinessHours"
+	if len(c.BusinessWindows) > 0 {
+		windowsField = "BusinessWindows"
+	}
+	if err := validateWindows(c.windows()); err != nil {
+		return withField(windowsField, err)
+	}
+
+	// Validate ValidDays
+	if len(c.ValidDays) == 0 {
+		return &ValidationError{Field: "ValidDays", Err: ErrNoValidDays}
+	}
+	for i, day := range c.ValidDays {
+		if day < time.Sunday || day > time.Saturday {
+			return &ValidationError{Field: fmt.Sprintf("ValidDays[%d]", i), Err: ErrInvalidDay}
+		}
+	}
+
+	// Overnight windows must not run into the next valid day's windows
+	if err := c.schedule().validateOvernight(); err != nil {
+		return withField(windowsField, err)
+	}
+	return nil
+}
+
+// validateDates checks the holidays, reduced and extra hours and closures on specific dates
+func (c *Calendar) validateDates() error {
+	// Validate CountryCode (optional, as holidays may not be a country's public holidays)
+	if c.CountryCode != "" && !isCountryCode(c.CountryCode) {
+		return &ValidationError{Field: "CountryCode", Err: fmt.Errorf("%w: %q", ErrInvalidCountryCode, c.CountryCode)}
+	}
+
+	// Validate Holidays (optional, as holidays are valid dates)
+	for i, holiday := range c.Holidays {
+		if holiday.IsZero() {
+			return &ValidationError{Field: fmt.Sprintf("Holidays[%d]", i), Err: ErrInvalidHoliday}
+		}
+	}
+
+	// Validate ReducedHours
+	for i, dayHours := range c.ReducedHours {
+		if err := dayHours.validate(); err != nil {
+			return withField(fmt.Sprintf("ReducedHours[%d]", i), err)
+		}
+	}
+
+	// Validate ExtraHours
+	for i, dayHours := range c.ExtraHours {
+		if err := dayHours.validate(); err != nil {
+			return withField(fmt.Sprintf("ExtraHours[%d]", i), err)
+		}
+	}
+
+	// Validate Closures
+	for i, closure := range c.Closures {
+		if err := closure.validate(); err != nil {
+			return withField(fmt.Sprintf("Closures[%d]", i), err)
+		}
+	}
+
+	return nil
+}
+
+// IsOpen reports whether t falls within business time, including an overnight window that opened the
+// previous day. An invalid calendar is never open.
+func (c *Calendar) IsOpen(t time.Time) bool {
+	if c.valid() != nil {
+		return false
+	}
+	return c.isOpen(t)
+}
+
+// NextOpen returns t when it falls within business time, or the next opening time after it
+func (c *Calendar) NextOpen(t time.Time) (time.Time, error) {
+	if err := c.valid(); err != nil {
+		return time.Time{}, err
+	}
+	opening, _ := c.nextBusinessInterval(t)
+	return opening, nil
+}
+
+// AddBusinessTime returns the instant the given amount of business time after t, or before it when d is negative.
+// Adding business time that runs out exactly at a closing time returns the closing time, and subtracting
+// business time that runs out exactly at an opening time returns the opening time.
+func (c *Calendar) AddBusinessTime(t time.Time, d time.Duration) (time.Time, error) {
+	if err := c.valid(); err != nil {
+		return time.Time{}, err
+	}
+	if d < 0 {
+		return c.subtractBusinessTime(t, -d), nil
+	}
+	return c.addRunningTime(t, d, nil), nil
+}
+
+// BusinessTimeBetween returns the exact business time between two instants. Either instant may fall
+// outside business hours, and the result is negative when to is before from.
+func (c *Calendar) BusinessTimeBetween(from, to time.Time) (time.Duration, error) {
+	if err := c.valid(); err != nil {
+		return 0, err
+	}
+	if to.Before(from) {
+		return -c.runningTimeBetween(to, from, nil), nil
+	}
+	return c.runningTimeBetween(from, to, nil), nil
+}
+
+// addRunningTime returns the instant at which the given amount of running clock time has passed after t
+func (c *Calendar) addRunningTime(t time.Time, d time.Duration, pauses []interval) time.Time {
+	if d <= 0 {
+		return t
+	}
+	if !c.singleUse {
+		return c.indexedAddRunning(t, d, pauses)
+	}
+
+	remainingDuration := d
+	clockTime := t
+
+	for {
+		// Consume the running interval we are in, or the next one to start
+		intervalStart, intervalEnd := c.nextClockInterval(clockTime, pauses)
+		available := intervalEnd.Sub(intervalStart)
+
+		// The deadline falls inside this interval
+		if remainingDuration <= available {
+			return intervalStart.Add(remainingDuration)
+		}
+
+		remainingDuration -= available
+		clockTime = intervalEnd
+	}
+}
+
+// subtractBusinessTime returns the instant that lies the given amount of business time before t.
+// When the subtraction lands exactly on an opening time, the opening time is returned rather than the
+// previous closing time.
+func (c *Calendar) subtractBusinessTime(t time.Time, d time.Duration) time.Time {
+	if d <= 0 {
+		return t
+	}
+	if !c.singleUse {
+		return c.indexedSubtract(t, d)
+	}
+
+	remainingDuration := d
+	currentTime := t.In(c.location())
+
+	for {
+		// Consume the business interval we are in, or the last one to close, walking backwards
+		intervalStart, intervalEnd := c.previousBusinessInterval(currentTime)
+		available := intervalEnd.Sub(intervalStart)
+
+		// The start falls inside this interval
+		if remainingDuration <= available {
+			return intervalEnd.Add(-remainingDuration)
+		}
+
+		remainingDuration -= available
+		currentTime = intervalStart
+	}
+}
+
+// addDays returns the same time of day the given number of calendar days and then business days after t,
+// rolled forward to the next opening time when the business is closed at that time. A business day is a
+// day on which at least one business window opens. When t falls outside business hours the days are
+// counted from the next opening, so a start at the weekend gets as many business hours as one on Friday.
+func (c *Calendar) addDays(t time.Time, calendarDays, businessDays int) time.Time {
+	t, _ = c.nextBusinessInterval(t)
+	t = t.In(c.location())
+	day := civilDay(t, calendarDays)
+	for businessDays > 0 {
+		day = civilDay(day, 1)
+		if len(c.businessIntervals(day)) > 0 {
+			businessDays--
+		}
+	}
+
+	opening, _ := c.nextBusinessInterval(sameTimeOn(day, t))
+	return opening
+}
+
+// subtractDays returns the same time of day the given number of business days and then calendar days before t,
+// rolled back to the previous closing time when the business is closed at that time. Like addDays, when t
+// falls outside business hours the days are counted back from the previous closing.
+func (c *Calendar) subtractDays(t time.Time, calendarDays, businessDays int) time.Time {
+	if !c.isOpen(t) {
+		_, t = c.previousBusinessInterval(t)
+	}
+	t = t.In(c.location())
+	day := civilDay(t, 0)
+	for businessDays > 0 {
+		day = civilDay(day, -1)
+		if len(c.businessIntervals(day)) > 0 {
+			businessDays--
+		}
+	}
+	day = civilDay(day, -calendarDays)
+
+	_, closing := c.previousBusinessInterval(sameTimeOn(day, t))
+	return closing
+}
+
+// civilDay returns noon on the calendar day of t, moved by the given number of days. Days are stepped
+// at noon rather than midnight because some zones, e.g. America/Santiago, change their clocks at midnight,
+// and time.Date resolves their missing midnight to the evening before.
+func civilDay(t time.Time, days int) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day()+days, 12, 0, 0, 0, t.Location())
+}
+
+// sameTimeOn returns t's time of day on the calendar day of day, resolving a time skipped by a daylight
+// saving transition like TimeOfDay does
+func sameTimeOn(day, t time.Time) time.Time {
+	sameTime := NewTimeOfDay(t.Hour(), t.Minute()).on(day.Year(), day.Month(), day.Day(), t.Location())
+	return sameTime.Add(time.Duration(t.Second())*time.Second + time.Duration(t.Nanosecond()))
+}
+
+// interval is a span of business time, open from start up to but excluding end
+type interval struct {
+	start time.Time
+	end   time.Time
+}
+
+// nextBusinessInterval returns the business interval containing t, or the next one to open after t.
+// When t is already within business time the interval starts at t rather than at opening time.
+// Opening and closing times are resolved on each day's wall clock, so they do not move on days with
+// a daylight saving transition; the business time between them is measured in elapsed time.
+func (c *Calendar) nextBusinessInterval(t time.Time) (time.Time, time.Time) {
+	if !c.singleUse {
+		return c.indexedNextInterval(t)
+	}
+	t = t.In(c.location())
+
+	// Start from the previous day, whose overnight window may still be open
+	day := civilDay(t, -1)
+	for {
+		for _, businessInterval := range c.businessIntervals(day) {
+			if t.Before(businessInterval.end) {
+				if t.Before(businessInterval.start) {
+					return businessInterval.start, businessInterval.end
+				}
+				return t, businessInterval.end
+			}
+		}
+
+		// Closed for the rest of the day, move to the next day. Days are stepped on the calendar
+		// rather than by 24 hours so that daylight saving transitions cannot shift the opening time.
+		day = civilDay(day, 1)
+	}
+}
+
+// previousBusinessInterval returns the business interval containing t, or the last one to close before t.
+// When t is within business time the interval ends at t rather than at closing time.
+func (c *Calendar) previousBusinessInterval(t time.Time) (time.Time, time.Time) {
+	if !c.singleUse {
+		return c.indexedPreviousInterval(t)
+	}
+	t = t.In(c.location())
+
+	// Start from t's own day; intervals opening on later days cannot have started yet
+	day := civilDay(t, 0)
+	for {
+		intervals := c.businessIntervals(day)
+		for i := len(intervals) - 1; i >= 0; i-- {
+			if intervals[i].start.Before(t) {
+				if intervals[i].end.After(t) {
+					return intervals[i].start, t
+				}
+				return intervals[i].start, intervals[i].end
+			}
+		}
+
+		// Nothing open earlier in the day, move to the previous day
+		day = civilDay(day, -1)
+	}
+}
+
+// businessIntervals returns the business intervals that open on the calendar day of the given time,
+// ordered by opening time. Overnight windows close on the following day but still belong to the
+// day they open on, so it is that day's weekday and holidays that decide whether they are open.
+// Extra hours are open even on holidays, and closures are cut out of whatever is open.
+func (c *Calendar) businessIntervals(day time.Time) []interval {
+	day = day.In(c.location())
+
+	windows := c.windowsOn(day)
+	// The windows are shared with other days, so never append to them in place
+	extraHours := c.extraHoursOn(day)
+	windows = append(windows[:len(windows):len(windows)], extraHours...)
+
+	year, month, date := day.Date()
+	intervals := make([]interval, 0, len(windows))
+	for _, window := range windows {
+		closingDate := date
+		if window.overnight() {
+			closingDate++
+		}
+
+		intervals = append(intervals, interval{
+			start: window.Start.on(year, month, date, day.Location()),
+			end:   window.End.on(year, month, closingDate, day.Location()),
+		})
+	}
+
+	// Extra hours may overlap the usual windows
+	if len(extraHours) > 0 {
+		intervals = mergeIntervals(intervals)
+	}
+	return c.withoutClosures(intervals)
+}
+
+// windows returns the daily business windows ordered by opening time
+func (c *Calendar) windows() []BusinessHours {
+	if len(c.BusinessWindows) == 0 {
+		return []BusinessHours{c.BusinessHours}
+	}
+	return sortWindows(c.BusinessWindows)
+}
+
+// schedule returns the weekly schedule, expanding ValidDays and BusinessHours when no Schedule is set
+func (c *Calendar) schedule() WeeklySchedule {
+	if c.Schedule != nil {
+		return c.Schedule
+	}
+
+	schedule := make(WeeklySchedule, len(c.ValidDays))
+	for _, day := range c.ValidDays {
+		schedule[day] = c.windows()
+	}
+	return schedule
+}
+
+// windowsOn returns the business windows for the day the given time falls on, ordered by opening time.
+// Reduced hours on that date replace the usual windows for its weekday, even when it is also a holiday.
+func (c *Calendar) windowsOn(t time.Time) []BusinessHours {
+	t = t.In(c.location())
+	dates := c.compiledDates()
+	if !c.IgnoreHolidays {
+		if windows, ok := dates.reducedHours[dateOf(t)]; ok {
+			return windows
+		}
+	}
+	if c.isHoliday(t) {
+		return nil
+	}
+	return dates.weekly[t.Weekday()]
+}
+
+// isOpen checks if the given time is within the defined business hours,
+// including an overnight window that opened the previous day
+func (c *Calendar) isOpen(t time.Time) bool {
+	if !c.singleUse {
+		opening, _ := c.indexedNextInterval(t)
+		return opening.Equal(t)
+	}
+	t = t.In(c.location())
+	for _, day := range []time.Time{civilDay(t, -1), t} {
+		for _, businessInterval := range c.businessIntervals(day) {
+			if !t.Before(businessInterval.start) && t.Before(businessInterval.end) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// isHoliday checks if the given time falls on a holiday.
+// Holidays are matched by their calendar date, whatever zone they were created in.
+func (c *Calendar) isHoliday(t time.Time) bool {
+	if c.IgnoreHolidays {
+		return false
+	}
+	return c.compiledDates().holidays[dateOf(t.In(c.location()))]
+}
+
+// isCountryCode reports whether the code is two upper-case letters, like the codes accepted by holidays.FetchHolidays
+func isCountryCode(code string) bool {
+	if len(code) != 2 {
+		return false
+	}
+	for _, letter := range code {
+		if letter < 'A' || letter > 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
+// location returns the time zone the calendar is interpreted in
+func (c *Calendar) location() *time.Location {
+	if c.Location == nil {
+		return time.UTC
+	}
+	return c.Location
+}
@@ -0,0 +1,187 @@
+package slachecker
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func setupCalendar() *Calendar {
+	return &Calendar{
+		BusinessHours: BusinessHours{Start: NewTimeOfDay(9, 0), End: NewTimeOfDay(17, 0)},
+		ValidDays:     []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday},
+		Holidays: []time.Time{
+			time.Date(2024, time.August, 26, 0, 0, 0, 0, time.UTC), // Holiday on Monday August 26, 2024
+		},
+	}
+}
+
+func TestCalendarIsOpenAndNextOpen(t *testing.T) {
+	calendar := setupCalendar()
+
+	tests := []struct {
+		name             string
+		time             time.Time
+		expectedOpen     bool
+		expectedNextOpen time.Time
+	}{
+		{
+			name:             "open",
+			time:             time.Date(2024, time.August, 27, 10, 0, 0, 0, time.UTC),
+			expectedOpen:     true,
+			expectedNextOpen: time.Date(2024, time.August, 27, 10, 0, 0, 0, time.UTC),
+		},
+		{
+			name:             "before opening",
+			time:             time.Date(2024, time.August, 27, 8, 0, 0, 0, time.UTC),
+			expectedNextOpen: time.Date(2024, time.August, 27, 9, 0, 0, 0, time.UTC),
+		},
+		{
+			name:             "at closing time",
+			time:             time.Date(2024, time.August, 27, 17, 0, 0, 0, time.UTC),
+			expectedNextOpen: time.Date(2024, time.August, 28, 9, 0, 0, 0, time.UTC),
+		},
+		{
+			name:             "weekend before a holiday",
+			time:             time.Date(2024, time.August, 24, 12, 0, 0, 0, time.UTC),
+			expectedNextOpen: time.Date(2024, time.August, 27, 9, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, test := range tests {
+		if open := calendar.IsOpen(test.time); open != test.expectedOpen {
+			t.Errorf("%s: expected IsOpen to be %v, but got %v", test.name, test.expectedOpen, open)
+		}
+
+		nextOpen, err := calendar.NextOpen(test.time)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", test.name, err)
+		}
+		if !nextOpen.Equal(test.expectedNextOpen) {
+			t.Errorf("%s: expected next opening %v, but got %v", test.name, test.expectedNextOpen, nextOpen)
+		}
+	}
+}
+
+func TestCalendarAddBusinessTime(t *testing.T) {
+	calendar := setupCalendar()
+
+	tests := []struct {
+		name     string
+		time     time.Time
+		duration time.Duration
+		expected time.Time
+	}{
+		{
+			name:     "same day",
+			time:     time.Date(2024, time.August, 27, 10, 0, 0, 0, time.UTC),
+			duration: 2 * time.Hour,
+			expected: time.Date(2024, time.August, 27, 12, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "over a holiday weekend",
+			time:     time.Date(2024, time.August, 23, 15, 0, 0, 0, time.UTC),
+			duration: 4 * time.Hour,
+			expected: time.Date(2024, time.August, 27, 11, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "backwards over a holiday weekend",
+			time:     time.Date(2024, time.August, 27, 11, 0, 0, 0, time.UTC),
+			duration: -4 * time.Hour,
+			expected: time.Date(2024, time.August, 23, 15, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "nothing to add",
+			time:     time.Date(2024, time.August, 24, 12, 0, 0, 0, time.UTC),
+			expected: time.Date(2024, time.August, 24, 12, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, test := range tests {
+		result, err := calendar.AddBusinessTime(test.time, test.duration)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", test.name, err)
+		}
+		if !result.Equal(test.expected) {
+			t.Errorf("%s: expected %v, but got %v", test.name, test.expected, result)
+		}
+
+		between, err := calendar.BusinessTimeBetween(test.time, result)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", test.name, err)
+		}
+		if between != test.duration {
+			t.Errorf("%s: expected %v of business time between, but got %v", test.name, test.duration, between)
+		}
+	}
+}
+
+func TestCalendarValidate(t *testing.T) {
+	calendar := setupCalendar()
+	calendar.ValidDays = nil
+
+	err := calendar.Validate()
+	if !errors.Is(err, ErrNoValidDays) {
+		t.Errorf("Expected error %v, but got %v", ErrNoValidDays, err)
+	}
+	if _, err := calendar.AddBusinessTime(time.Now(), time.Hour); !errors.Is(err, ErrNoValidDays) {
+		t.Errorf("Expected AddBusinessTime to fail with %v, but got %v", ErrNoValidDays, err)
+	}
+	if calendar.IsOpen(time.Date(2024, time.August, 27, 10, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected an invalid calendar to be closed")
+	}
+
+	// SLAs report errors in a shared calendar against the Calendar field
+	sla := SLA{StartTime: time.Now(), SLALength: 4, TimeUnit: "hours", Calendar: calendar}
+	var validationErr *ValidationError
+	if !errors.As(sla.Validate(), &validationErr) || validationErr.Field != "Calendar.ValidDays" {
+		t.Errorf("Expected a validation error for Calendar.ValidDays, but got %v", sla.Validate())
+	}
+}
+
+func TestSLAWithSharedCalendar(t *testing.T) {
+	calendar := setupCalendar()
+
+	// The same SLA with its own calendar fields, which are ignored when a shared calendar is set
+	inline := setupSLAWithHolidays(calendar.Holidays)
+	shared := SLA{
+		StartTime:     inline.StartTime,
+		SLALength:     inline.SLALength,
+		TimeUnit:      inline.TimeUnit,
+		BusinessHours: BusinessHours{Start: NewTimeOfDay(0, 0), End: NewTimeOfDay(1, 0)},
+		Calendar:      calendar,
+	}
+
+	for _, startTime := range []time.Time{
+		time.Date(2024, time.August, 23, 15, 0, 0, 0, time.UTC),
+		time.Date(2024, time.August, 27, 16, 30, 0, 0, time.UTC),
+	} {
+		inline.StartTime = startTime
+		shared.StartTime = startTime
+		currentTime := startTime.Add(time.Hour)
+
+		expected, err := inline.Evaluate(currentTime)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		result, err := shared.Evaluate(currentTime)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if result != expected {
+			t.Errorf("Expected the shared calendar to give %+v, but got %+v", expected, result)
+		}
+	}
+
+	// Calendar time still skips the shared calendar's holidays
+	shared.ClockMode = ClockModeCalendarSkipHolidays
+	shared.StartTime = time.Date(2024, time.August, 25, 22, 0, 0, 0, time.UTC) // Sunday 10 PM
+	deadline, err := shared.Deadline(shared.StartTime)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := time.Date(2024, time.August, 27, 2, 0, 0, 0, time.UTC)
+	if !deadline.Equal(expected) {
+		t.Errorf("Expected deadline to be %v, but got %v", expected, deadline)
+	}
+}
@@ -0,0 +1,60 @@
+package slachecker
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock tells the current time to EvaluateNow, CheckNow and the other methods evaluating as of now
+type Clock interface {
+	Now() time.Time
+}
+
+// RealClock is the system clock
+type RealClock struct{}
+
+// Now returns the current system time
+func (RealClock) Now() time.Time {
+	return time.Now()
+}
+
+// FakeClock is a manual clock for tests, which only moves when it is set or advanced.
+// It is safe for concurrent use.
+type FakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+// NewFakeClock creates a FakeClock frozen at the given time
+func NewFakeClock(now time.Time) *FakeClock {
+	return &FakeClock{now: now}
+}
+
+// Now returns the time the clock is frozen at
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// Set moves the clock to the given time, which may be in the past
+func (c *FakeClock) Set(now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = now
+}
+
+// Advance moves the clock forward by d, or back when d is negative
+func (c *FakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+// now returns the current time from the given clock, or from the system clock when it is nil
+func now(clock Clock) time.Time {
+	if clock == nil {
+		return RealClock{}.Now()
+	}
+	return clock.Now()
+}
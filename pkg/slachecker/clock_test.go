@@ -0,0 +1,101 @@
+package slachecker
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestFakeClock(t *testing.T) {
+	start := time.Date(2024, time.August, 27, 16, 59, 0, 0, time.UTC)
+	clock := NewFakeClock(start)
+
+	if now := clock.Now(); !now.Equal(start) {
+		t.Errorf("Expected Now to be %v, but got %v", start, now)
+	}
+
+	clock.Advance(2 * time.Minute)
+	if expected, now := start.Add(2*time.Minute), clock.Now(); !now.Equal(expected) {
+		t.Errorf("Expected Now to be %v after advancing, but got %v", expected, now)
+	}
+
+	clock.Set(start.Add(-time.Hour))
+	if expected, now := start.Add(-time.Hour), clock.Now(); !now.Equal(expected) {
+		t.Errorf("Expected Now to be %v after setting, but got %v", expected, now)
+	}
+}
+
+func TestEvaluateNowWithFakeClock(t *testing.T) {
+	// Two business hours from Tuesday 4 PM, due at Wednesday 10 AM after closing at 5 PM
+	clock := NewFakeClock(time.Date(2024, time.August, 27, 16, 30, 0, 0, time.UTC))
+	sla := SLA{
+		StartTime: time.Date(2024, time.August, 27, 16, 0, 0, 0, time.UTC),
+		Length:    "PT2H",
+		Calendar:  setupCalendar(),
+		Clock:     clock,
+	}
+
+	tests := []struct {
+		name              string
+		advance           time.Duration
+		expectedStatus    Status
+		expectedRemaining time.Duration
+	}{
+		{name: "before closing", expectedStatus: StatusOnTrack, expectedRemaining: 90 * time.Minute},
+		{name: "at closing", advance: 30 * time.Minute, expectedStatus: StatusOnTrack, expectedRemaining: time.Hour},
+		{name: "overnight", advance: 12 * time.Hour, expectedStatus: StatusOnTrack, expectedRemaining: time.Hour},
+		{name: "at the deadline", advance: 5 * time.Hour, expectedStatus: StatusBreached, expectedRemaining: 0},
+	}
+
+	for _, test := range tests {
+		clock.Advance(test.advance)
+
+		result, err := sla.EvaluateNow()
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", test.name, err)
+		}
+		if result.Status != test.expectedStatus {
+			t.Errorf("%s: expected status %q, but got %q", test.name, test.expectedStatus, result.Status)
+		}
+		if result.WorkingTimeRemainingDuration != test.expectedRemaining {
+			t.Errorf("%s: expected working time remaining %v, but got %v", test.name, test.expectedRemaining, result.WorkingTimeRemainingDuration)
+		}
+		if checked := sla.CheckNow(); checked.Status != result.Status {
+			t.Errorf("%s: expected CheckNow status %q, but got %q", test.name, result.Status, checked.Status)
+		}
+	}
+}
+
+func TestEvaluateNowWithClocks(t *testing.T) {
+	frozen := time.Date(2024, time.August, 27, 12, 0, 0, 0, time.UTC)
+	clock := NewFakeClock(frozen)
+
+	policy := setupPolicy()
+	policy.SLA.Clock = clock
+	result, err := policy.EvaluateNow()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected, _ := policy.Evaluate(frozen)
+	if result.Status != expected.Status {
+		t.Errorf("Expected policy status %q, but got %q", expected.Status, result.Status)
+	}
+
+	evaluator := BatchEvaluator{Calendar: setupCalendar(), Clock: clock}
+	results, err := evaluator.EvaluateNow(context.Background(), setupBatch(3))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expectedResults, _ := evaluator.Evaluate(context.Background(), setupBatch(3), frozen)
+	for i, result := range results {
+		if !result.Result.Deadline.Equal(expectedResults[i].Result.Deadline) || result.Result.Status != expectedResults[i].Result.Status {
+			t.Errorf("Expected result %d to be %v, but got %v", i, expectedResults[i].Result, result.Result)
+		}
+	}
+
+	// Without a Clock the system clock is used
+	sla := SLA{StartTime: time.Now().Add(-time.Hour), Length: "PT2H", ClockMode: ClockModeCalendar}
+	if result := sla.CheckNow(); result.Status != StatusOnTrack {
+		t.Errorf("Expected status %q with the system clock, but got %q", StatusOnTrack, result.Status)
+	}
+}
@@ -0,0 +1,38 @@
+package slachecker
+
+import "time"
+
+// ClockMode decides which hours the SLA clock runs during
+type ClockMode string
+
+// Clock modes accepted in SLA.ClockMode
+const (
+	ClockModeBusiness             ClockMode = "business"             // Only during business hours on valid days, skipping holidays; the default
+	ClockModeCalendar             ClockMode = "calendar"             // Around the clock every day, including holidays and closures
+	ClockModeCalendarSkipHolidays ClockMode = "calendarSkipHolidays" // Around the clock every day except holidays
+)
+
+// isValid checks the clock mode is one of the ClockMode constants, or empty for the default
+func (m ClockMode) isValid() bool {
+	switch m {
+	case "", ClockModeBusiness, ClockModeCalendar, ClockModeCalendarSkipHolidays:
+		return true
+	default:
+		return false
+	}
+}
+
+// isCalendar reports whether the clock runs around the clock rather than during business hours
+func (m ClockMode) isCalendar() bool {
+	return m == ClockModeCalendar || m == ClockModeCalendarSkipHolidays
+}
+
+// calendarSchedule returns a schedule that is open all day, every day of the week
+func calendarSchedule() WeeklySchedule {
+	allDay := []BusinessHours{{Start: NewTimeOfDay(0, 0), End: NewTimeOfDay(24, 0)}}
+	schedule := make(WeeklySchedule, 7)
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		schedule[day] = allDay
+	}
+	return schedule
+}
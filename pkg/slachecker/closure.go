@@ -0,0 +1,79 @@
+package slachecker
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Closure is a period during which the business is closed, whatever the schedule says, e.g. an office move
+type Closure struct {
+	Start time.Time
+	End   time.Time
+}
+
+// validate checks the closure starts, and ends after it starts
+func (c Closure) validate() error {
+	if c.Start.IsZero() || c.End.IsZero() {
+		return fmt.Errorf("%w: start and end cannot be empty", ErrInvalidClosure)
+	}
+	if !c.End.After(c.Start) {
+		return fmt.Errorf("%w: end must be after start", ErrInvalidClosure)
+	}
+	return nil
+}
+
+// extraHoursOn returns the extra business windows on the calendar day of the given time
+func (c *Calendar) extraHoursOn(day time.Time) []BusinessHours {
+	return c.compiledDates().extraHours[dateOf(day)]
+}
+
+// mergeIntervals returns the intervals ordered by start, with overlapping and touching intervals joined
+func mergeIntervals(intervals []interval) []interval {
+	sort.Slice(intervals, func(i, j int) bool {
+		return intervals[i].start.Before(intervals[j].start)
+	})
+
+	merged := intervals[:0]
+	for _, next := range intervals {
+		if last := len(merged) - 1; last >= 0 && !next.start.After(merged[last].end) {
+			if next.end.After(merged[last].end) {
+				merged[last].end = next.end
+			}
+			continue
+		}
+		merged = append(merged, next)
+	}
+	return merged
+}
+
+// withoutClosures returns the intervals with every closure cut out of them
+func (c *Calendar) withoutClosures(intervals []interval) []interval {
+	for _, closure := range c.Closures {
+		intervals = withoutClosure(intervals, closure)
+	}
+	return intervals
+}
+
+// withoutClosure returns the intervals with the closure cut out of them
+func withoutClosure(intervals []interval, closure Closure) []interval {
+	open := make([]interval, 0, len(intervals))
+	for _, businessInterval := range intervals {
+		// Keep the parts of the interval before and after the closure
+		if businessInterval.start.Before(closure.Start) {
+			end := businessInterval.end
+			if closure.Start.Before(end) {
+				end = closure.Start
+			}
+			open = append(open, interval{start: businessInterval.start, end: end})
+		}
+		if closure.End.Before(businessInterval.end) {
+			start := businessInterval.start
+			if start.Before(closure.End) {
+				start = closure.End
+			}
+			open = append(open, interval{start: start, end: businessInterval.end})
+		}
+	}
+	return open
+}
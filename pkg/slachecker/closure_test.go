@@ -0,0 +1,66 @@
+package slachecker
+
+import (
+	"testing"
+	"time"
+)
+
+func TestClosureValidate(t *testing.T) {
+	start := time.Date(2024, time.September, 3, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		closure     Closure
+		expectError bool
+	}{
+		{name: "valid closure", closure: Closure{Start: start, End: start.Add(time.Hour)}},
+		{name: "missing start", closure: Closure{End: start}, expectError: true},
+		{name: "missing end", closure: Closure{Start: start}, expectError: true},
+		{name: "end before start", closure: Closure{Start: start, End: start.Add(-time.Minute)}, expectError: true},
+		{name: "empty closure", closure: Closure{Start: start, End: start}, expectError: true},
+	}
+
+	for _, test := range tests {
+		err := test.closure.validate()
+		if test.expectError && err == nil {
+			t.Errorf("%s: expected a validation error, but got none", test.name)
+		}
+		if !test.expectError && err != nil {
+			t.Errorf("%s: unexpected validation error: %v", test.name, err)
+		}
+	}
+}
+
+func TestWithoutClosures(t *testing.T) {
+	at := func(hour int) time.Time {
+		return time.Date(2024, time.September, 3, hour, 0, 0, 0, time.UTC)
+	}
+
+	sla := setupSLAWithHolidays(nil)
+	sla.Closures = []Closure{
+		{Start: at(10), End: at(11)}, // Inside the morning
+		{Start: at(12), End: at(14)}, // Over the end of the morning and the start of the afternoon
+	}
+
+	intervals := sla.calendar().withoutClosures([]interval{{start: at(9), end: at(13)}, {start: at(13), end: at(17)}})
+	expected := []interval{{start: at(9), end: at(10)}, {start: at(11), end: at(12)}, {start: at(14), end: at(17)}}
+	if len(intervals) != len(expected) {
+		t.Fatalf("Expected %d intervals, but got %d: %v", len(expected), len(intervals), intervals)
+	}
+	for i := range expected {
+		if !intervals[i].start.Equal(expected[i].start) || !intervals[i].end.Equal(expected[i].end) {
+			t.Errorf("Expected interval %d to be %v-%v, but got %v-%v", i, expected[i].start, expected[i].end, intervals[i].start, intervals[i].end)
+		}
+	}
+}
+
+func TestMergeIntervals(t *testing.T) {
+	at := func(hour int) time.Time {
+		return time.Date(2024, time.September, 3, hour, 0, 0, 0, time.UTC)
+	}
+
+	intervals := mergeIntervals([]interval{{start: at(16), end: at(19)}, {start: at(9), end: at(12)}, {start: at(13), end: at(17)}, {start: at(12), end: at(13)}})
+	if len(intervals) != 1 || !intervals[0].start.Equal(at(9)) || !intervals[0].end.Equal(at(19)) {
+		t.Errorf("Expected a single interval from 09:00 to 19:00, but got %v", intervals)
+	}
+}
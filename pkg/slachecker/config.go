@@ -0,0 +1,561 @@
+package slachecker
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// dateLayout is the ISO 8601 calendar date format holidays and dated hours are written in
+const dateLayout = "2006-01-02"
+
+// slaConfig is the JSON and YAML representation of an SLA
+type slaConfig struct {
+	StartTime      *time.Time       `json:"startTime,omitempty" yaml:"startTime,omitempty"`
+	SLALength      int              `json:"slaLength,omitempty" yaml:"slaLength,omitempty"`
+	TimeUnit       string           `json:"timeUnit,omitempty" yaml:"timeUnit,omitempty"`
+	Length         string           `json:"length,omitempty" yaml:"length,omitempty"`
+	calendarConfig `yaml:",inline"` // The SLA's own calendar fields
+	Calendar       *calendarConfig  `json:"calendar,omitempty" yaml:"calendar,omitempty"`
+	Pauses         []pauseConfig    `json:"pauses,omitempty" yaml:"pauses,omitempty"`
+	ClockMode      ClockMode        `json:"clockMode,omitempty" yaml:"clockMode,omitempty"`
+	CompletedAt    *time.Time       `json:"completedAt,omitempty" yaml:"completedAt,omitempty"`
+	AtRisk         *atRiskConfig    `json:"atRisk,omitempty" yaml:"atRisk,omitempty"`
+}
+
+// calendarConfig is the JSON and YAML representation of a Calendar
+type calendarConfig struct {
+	BusinessHours   *hoursConfig             `json:"businessHours,omitempty" yaml:"businessHours,omitempty"`
+	BusinessWindows []hoursConfig            `json:"businessWindows,omitempty" yaml:"businessWindows,omitempty"`
+	Schedule        map[string][]hoursConfig `json:"schedule,omitempty" yaml:"schedule,omitempty"` // Keyed by weekday name
+	ValidDays       []string                 `json:"validDays,omitempty" yaml:"validDays,omitempty"`
+	Holidays        []string                 `json:"holidays,omitempty" yaml:"holidays,omitempty"`
+	ReducedHours    []dayHoursConfig         `json:"reducedHours,omitempty" yaml:"reducedHours,omitempty"`
+	ExtraHours      []dayHoursConfig         `json:"extraHours,omitempty" yaml:"extraHours,omitempty"`
+	Closures        []closureConfig          `json:"closures,omitempty" yaml:"closures,omitempty"`
+	IgnoreHolidays  bool                     `json:"ignoreHolidays,omitempty" yaml:"ignoreHolidays,omitempty"`
+	Location        string                   `json:"location,omitempty" yaml:"location,omitempty"` // IANA time zone name, e.g. "Europe/London"
+	CountryCode     string                   `json:"countryCode,omitempty" yaml:"countryCode,omitempty"`
+}
+
+// hoursConfig is a business window with "HH:MM" opening and closing times
+type hoursConfig struct {
+	Start string `json:"start" yaml:"start"`
+	End   string `json:"end" yaml:"end"`
+}
+
+// dayHoursConfig is the business windows on an ISO 8601 date
+type dayHoursConfig struct {
+	Date  string        `json:"date" yaml:"date"`
+	Hours []hoursConfig `json:"hours" yaml:"hours"`
+}
+
+type closureConfig struct {
+	Start time.Time `json:"start" yaml:"start"`
+	End   time.Time `json:"end" yaml:"end"`
+}
+
+type pauseConfig struct {
+	Start time.Time  `json:"start" yaml:"start"`
+	End   *time.Time `json:"end,omitempty" yaml:"end,omitempty"` // Missing while the pause is still open
+}
+
+type atRiskConfig struct {
+	PercentConsumed float64 `json:"percentConsumed,omitempty" yaml:"percentConsumed,omitempty"`
+	Remaining       string  `json:"remaining,omitempty" yaml:"remaining,omitempty"` // Go duration, e.g. "30m"
+}
+
+// MarshalJSON encodes the SLA in its configuration format, with named weekdays, "HH:MM" times and ISO 8601 dates.
+// The Clock is not encoded, and locations that are not IANA time zones fail with ErrInvalidLocation.
+func (s SLA) MarshalJSON() ([]byte, error) {
+	config, err := newSLAConfig(s)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(config)
+}
+
+// UnmarshalJSON decodes an SLA from its configuration format, keeping the current Clock, and validates it;
+// only a missing length is accepted, as a Policy's targets set their own. Values that cannot be parsed and
+// validation failures are reported as a *ValidationError naming the field by its path in the configuration,
+// e.g. "schedule.Monday[0].end" or "calendar.validDays".
+func (s *SLA) UnmarshalJSON(data []byte) error {
+	var config slaConfig
+	if err := json.Unmarshal(data, &config); err != nil {
+		return err
+	}
+	return config.decode(s)
+}
+
+// MarshalYAML encodes the SLA in the same configuration format as MarshalJSON
+func (s SLA) MarshalYAML() (interface{}, error) {
+	return newSLAConfig(s)
+}
+
+// UnmarshalYAML decodes an SLA from the same configuration format as UnmarshalJSON
+func (s *SLA) UnmarshalYAML(value *yaml.Node) error {
+	var config slaConfig
+	if err := value.Decode(&config); err != nil {
+		return err
+	}
+	return config.decode(s)
+}
+
+// MarshalJSON encodes the calendar in the same format as the calendar fields of an SLA
+func (c *Calendar) MarshalJSON() ([]byte, error) {
+	config, err := newCalendarConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(config)
+}
+
+// UnmarshalJSON decodes a calendar from the same format as the calendar fields of an SLA, and validates it.
+// It must not be used on a calendar that is already in use.
+func (c *Calendar) UnmarshalJSON(data []byte) error {
+	var config calendarConfig
+	if err := json.Unmarshal(data, &config); err != nil {
+		return err
+	}
+	return config.decodeCalendar(c)
+}
+
+// MarshalYAML encodes the calendar in the same configuration format as MarshalJSON
+func (c *Calendar) MarshalYAML() (interface{}, error) {
+	return newCalendarConfig(c)
+}
+
+// UnmarshalYAML decodes a calendar from the same configuration format as UnmarshalJSON
+func (c *Calendar) UnmarshalYAML(value *yaml.Node) error {
+	var config calendarConfig
+	if err := value.Decode(&config); err != nil {
+		return err
+	}
+	return config.decodeCalendar(c)
+}
+
+// newSLAConfig returns the configuration representation of the SLA
+func newSLAConfig(s SLA) (slaConfig, error) {
+	inline, err := newCalendarConfig(s.inlineCalendar())
+	if err != nil {
+		return slaConfig{}, err
+	}
+	config := slaConfig{
+		SLALength:      s.SLALength,
+		TimeUnit:       s.TimeUnit,
+		Length:         s.Length,
+		calendarConfig: inline,
+		ClockMode:      s.ClockMode,
+	}
+	if !s.StartTime.IsZero() {
+		config.StartTime = &s.StartTime
+	}
+	if s.Calendar != nil {
+		calendar, err := newCalendarConfig(s.Calendar)
+		if err != nil {
+			return slaConfig{}, withField("calendar", err)
+		}
+		config.Calendar = &calendar
+	}
+	for _, pause := range s.Pauses {
+		pauseConfig := pauseConfig{Start: pause.Start}
+		if !pause.End.IsZero() {
+			end := pause.End
+			pauseConfig.End = &end
+		}
+		config.Pauses = append(config.Pauses, pauseConfig)
+	}
+	if !s.CompletedAt.IsZero() {
+		config.CompletedAt = &s.CompletedAt
+	}
+	if s.AtRisk != (AtRiskThreshold{}) {
+		config.AtRisk = &atRiskConfig{PercentConsumed: s.AtRisk.PercentConsumed}
+		if s.AtRisk.Remaining != 0 {
+			config.AtRisk.Remaining = s.AtRisk.Remaining.String()
+		}
+	}
+	return config, nil
+}
+
+// decode replaces the SLA with the one the configuration describes, keeping its Clock, once it is valid
+func (config slaConfig) decode(s *SLA) error {
+	sla := SLA{
+		SLALength: config.SLALength,
+		TimeUnit:  config.TimeUnit,
+		Length:    config.Length,
+		ClockMode: config.ClockMode,
+		Clock:     s.Clock,
+	}
+	if config.StartTime != nil {
+		sla.StartTime = *config.StartTime
+	}
+
+	var inline Calendar
+	if err := config.calendarConfig.decode(&inline); err != nil {
+		return err
+	}
+	sla.BusinessHours = inline.BusinessHours
+	sla.BusinessWindows = inline.BusinessWindows
+	sla.Schedule = inline.Schedule
+	sla.ValidDays = inline.ValidDays
+	sla.Holidays = inline.Holidays
+	sla.ReducedHours = inline.ReducedHours
+	sla.ExtraHours = inline.ExtraHours
+	sla.Closures = inline.Closures
+	sla.IgnoreHolidays = inline.IgnoreHolidays
+	sla.Location = inline.Location
+	sla.CountryCode = inline.CountryCode
+
+	if config.Calendar != nil {
+		sla.Calendar = &Calendar{}
+		if err := config.Calendar.decodeCalendar(sla.Calendar); err != nil {
+			return withField("calendar", err)
+		}
+	}
+
+	for _, pause := range config.Pauses {
+		decoded := Pause{Start: pause.Start}
+		if pause.End != nil {
+			decoded.End = *pause.End
+		}
+		sla.Pauses = append(sla.Pauses, decoded)
+	}
+	if config.CompletedAt != nil {
+		sla.CompletedAt = *config.CompletedAt
+	}
+	if config.AtRisk != nil {
+		sla.AtRisk.PercentConsumed = config.AtRisk.PercentConsumed
+		if config.AtRisk.Remaining != "" {
+			remaining, err := time.ParseDuration(config.AtRisk.Remaining)
+			if err != nil {
+				return &ValidationError{Field: "atRisk.remaining", Err: fmt.Errorf("%w: %q", ErrInvalidDuration, config.AtRisk.Remaining)}
+			}
+			sla.AtRisk.Remaining = remaining
+		}
+	}
+
+	if err := sla.validateDecoded(); err != nil {
+		return withConfigField(err)
+	}
+	*s = sla
+	return nil
+}
+
+// validateDecoded checks the SLA like Validate, except that a missing length is left for a Policy's targets
+func (s *SLA) validateDecoded() error {
+	if s.Length != "" || s.SLALength != 0 || s.TimeUnit != "" {
+		if err := s.validateLength(); err != nil {
+			return err
+		}
+	}
+	if err := s.validateCalendar(s.ownCalendar()); err != nil {
+		return err
+	}
+	if err := s.validatePauses(); err != nil {
+		return err
+	}
+	return s.validateTarget()
+}
+
+// newCalendarConfig returns the configuration representation of the calendar
+func newCalendarConfig(c *Calendar) (calendarConfig, error) {
+	location, err := formatLocation(c.Location)
+	if err != nil {
+		return calendarConfig{}, err
+	}
+	config := calendarConfig{
+		BusinessWindows: newHoursConfigs(c.BusinessWindows),
+		Holidays:        formatDates(c.Holidays),
+		ReducedHours:    newDayHoursConfigs(c.ReducedHours),
+		ExtraHours:      newDayHoursConfigs(c.ExtraHours),
+		IgnoreHolidays:  c.IgnoreHolidays,
+		Location:        location,
+		CountryCode:     c.CountryCode,
+	}
+	if c.BusinessHours != (BusinessHours{}) {
+		config.BusinessHours = &hoursConfig{Start: c.BusinessHours.Start.String(), End: c.BusinessHours.End.String()}
+	}
+	if c.Schedule != nil {
+		config.Schedule = make(map[string][]hoursConfig, len(c.Schedule))
+		for day, windows := range c.Schedule {
+			config.Schedule[day.String()] = newHoursConfigs(windows)
+		}
+	}
+	for _, day := range c.ValidDays {
+		config.ValidDays = append(config.ValidDays, day.String())
+	}
+	for _, closure := range c.Closures {
+		config.Closures = append(config.Closures, closureConfig{Start: closure.Start, End: closure.End})
+	}
+	return config, nil
+}
+
+// decodeCalendar sets the calendar's fields to the ones the configuration describes, and validates it
+func (config calendarConfig) decodeCalendar(c *Calendar) error {
+	if err := config.decode(c); err != nil {
+		return err
+	}
+	return withConfigField(c.Validate())
+}
+
+// decode sets the calendar's fields to the ones the configuration describes. The SLA's own calendar fields
+// are decoded this way too, so they are not validated until the whole SLA is.
+func (config calendarConfig) decode(c *Calendar) error {
+	var err error
+	c.BusinessHours = BusinessHours{}
+	if config.BusinessHours != nil {
+		if c.BusinessHours, err = config.BusinessHours.decode(); err != nil {
+			return withField("businessHours", err)
+		}
+	}
+	if c.BusinessWindows, err = decodeHours(config.BusinessWindows); err != nil {
+		return withField("businessWindows", err)
+	}
+
+	c.Schedule = nil
+	if config.Schedule != nil {
+		c.Schedule = make(WeeklySchedule, len(config.Schedule))
+		for name, windows := range config.Schedule {
+			day, err := parseWeekday(name)
+			if err != nil {
+				return &ValidationError{Field: "schedule." + name, Err: err}
+			}
+			if c.Schedule[day], err = decodeHours(windows); err != nil {
+				return withField("schedule."+name, err)
+			}
+		}
+	}
+
+	c.ValidDays = nil
+	for i, name := range config.ValidDays {
+		day, err := parseWeekday(name)
+		if err != nil {
+			return &ValidationError{Field: fmt.Sprintf("validDays[%d]", i), Err: err}
+		}
+		c.ValidDays = append(c.ValidDays, day)
+	}
+
+	c.Holidays = nil
+	for i, value := range config.Holidays {
+		holiday, err := parseDate(value)
+		if err != nil {
+			return &ValidationError{Field: fmt.Sprintf("holidays[%d]", i), Err: err}
+		}
+		c.Holidays = append(c.Holidays, holiday)
+	}
+
+	if c.ReducedHours, err = decodeDayHours(config.ReducedHours); err != nil {
+		return withField("reducedHours", err)
+	}
+	if c.ExtraHours, err = decodeDayHours(config.ExtraHours); err != nil {
+		return withField("extraHours", err)
+	}
+
+	c.Closures = nil
+	for _, closure := range config.Closures {
+		c.Closures = append(c.Closures, Closure{Start: closure.Start, End: closure.End})
+	}
+
+	c.Location = nil
+	if config.Location != "" {
+		if c.Location, err = loadLocation(config.Location); err != nil {
+			return &ValidationError{Field: "location", Err: fmt.Errorf("%w: %v", ErrInvalidLocation, err)}
+		}
+	}
+
+	c.IgnoreHolidays = config.IgnoreHolidays
+	c.CountryCode = config.CountryCode
+	return nil
+}
+
+// decode parses the window's opening and closing times
+func (h hoursConfig) decode() (BusinessHours, error) {
+	start, err := ParseTimeOfDay(h.Start)
+	if err != nil {
+		return BusinessHours{}, &ValidationError{Field: "start", Err: fmt.Errorf("%w: %v", ErrInvalidBusinessHours, err)}
+	}
+	end, err := ParseTimeOfDay(h.End)
+	if err != nil {
+		return BusinessHours{}, &ValidationError{Field: "end", Err: fmt.Errorf("%w: %v", ErrInvalidBusinessHours, err)}
+	}
+	return BusinessHours{Start: start, End: end}, nil
+}
+
+// newHoursConfigs returns the configuration representation of the windows
+func newHoursConfigs(windows []BusinessHours) []hoursConfig {
+	var configs []hoursConfig
+	for _, window := range windows {
+		configs = append(configs, hoursConfig{Start: window.Start.String(), End: window.End.String()})
+	}
+	return configs
+}
+
+// decodeHours parses each of the windows, reporting a failure against its index
+func decodeHours(configs []hoursConfig) ([]BusinessHours, error) {
+	var windows []BusinessHours
+	for i, config := range configs {
+		window, err := config.decode()
+		if err != nil {
+			return nil, withField(fmt.Sprintf("[%d]", i), err)
+		}
+		windows = append(windows, window)
+	}
+	return windows, nil
+}
+
+// newDayHoursConfigs returns the configuration representation of the dated windows
+func newDayHoursConfigs(days []DayHours) []dayHoursConfig {
+	var configs []dayHoursConfig
+	for _, day := range days {
+		configs = append(configs, dayHoursConfig{Date: day.Date.Format(dateLayout), Hours: newHoursConfigs(day.Hours)})
+	}
+	return configs
+}
+
+// decodeDayHours parses each of the dated windows, reporting a failure against its index
+func decodeDayHours(configs []dayHoursConfig) ([]DayHours, error) {
+	var days []DayHours
+	for i, config := range configs {
+		date, err := parseDate(config.Date)
+		if err != nil {
+			return nil, &ValidationError{Field: fmt.Sprintf("[%d].date", i), Err: err}
+		}
+		hours, err := decodeHours(config.Hours)
+		if err != nil {
+			return nil, withField(fmt.Sprintf("[%d].hours", i), err)
+		}
+		days = append(days, DayHours{Date: date, Hours: hours})
+	}
+	return days, nil
+}
+
+// parseWeekday parses an English weekday name, e.g. "Monday", "monday" or "Mon"
+func parseWeekday(name string) (time.Weekday, error) {
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		if strings.EqualFold(name, day.String()) || strings.EqualFold(name, day.String()[:3]) {
+			return day, nil
+		}
+	}
+	return 0, fmt.Errorf("%w: %q", ErrInvalidDay, name)
+}
+
+// parseDate parses an ISO 8601 calendar date, e.g. "2024-12-25", as midnight UTC
+func parseDate(value string) (time.Time, error) {
+	date, err := time.Parse(dateLayout, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: %q is not a YYYY-MM-DD date", ErrInvalidHoliday, value)
+	}
+	return date, nil
+}
+
+// withConfigField reports a *ValidationError from Validate against the field's path in the configuration,
+// e.g. "Schedule[Monday]" becomes "schedule.Monday" and "Calendar.ValidDays[1]" becomes "calendar.validDays[1]"
+func withConfigField(err error) error {
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		return err
+	}
+
+	var field strings.Builder
+	path := validationErr.Field
+	for path != "" {
+		switch {
+		case path[0] == '.':
+			field.WriteByte('.')
+			path = path[1:]
+		case path[0] == '[':
+			// Indexes stay as they are, and weekday keys become keys of the schedule
+			end := strings.IndexByte(path, ']')
+			if end < 0 {
+				end = len(path) - 1
+			}
+			if key := path[1:end]; strings.Trim(key, "0123456789") != "" {
+				field.WriteString("." + key)
+			} else {
+				field.WriteString(path[:end+1])
+			}
+			path = path[end+1:]
+		default:
+			end := strings.IndexAny(path, ".[")
+			if end < 0 {
+				end = len(path)
+			}
+			field.WriteString(configKey(path[:end]))
+			path = path[end:]
+		}
+	}
+	return &ValidationError{Field: field.String(), Err: validationErr.Err}
+}
+
+// configKey returns the configuration key of a Go field name, which is the name in camel case
+func configKey(name string) string {
+	switch name {
+	case "":
+		return name
+	case "SLALength":
+		return "slaLength"
+	}
+	return strings.ToLower(name[:1]) + name[1:]
+}
+
+// locations caches the time zones loaded by name, so each zone's data is read once however many
+// configurations use it, and decoded SLAs and calendars share their *time.Location
+var locations sync.Map // map[string]*time.Location
+
+// loadLocation is time.LoadLocation with the result cached by name
+func loadLocation(name string) (*time.Location, error) {
+	if location, ok := locations.Load(name); ok {
+		return location.(*time.Location), nil
+	}
+	location, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, err
+	}
+	cached, _ := locations.LoadOrStore(name, location)
+	return cached.(*time.Location), nil
+}
+
+// formatLocation returns the IANA time zone name of the location, or an empty name for nil. Locations that
+// would not load back from their name with the same UTC offsets, such as a time.FixedZone, are rejected
+// rather than letting business hours shift once the configuration is decoded.
+func formatLocation(location *time.Location) (string, error) {
+	if location == nil {
+		return "", nil
+	}
+	name := location.String()
+	loaded, err := loadLocation(name)
+	if err != nil || (loaded != location && !sameOffsets(location, loaded)) {
+		return "", &ValidationError{Field: "location", Err: fmt.Errorf("%w: %q does not load back as the same time zone", ErrInvalidLocation, name)}
+	}
+	return name, nil
+}
+
+// sameOffsets reports whether two locations have the same UTC offset in January and July of every year
+// from 1970 to 2037, which tells a fixed zone from one with daylight saving time or another history
+func sameOffsets(a, b *time.Location) bool {
+	for year := 1970; year <= 2037; year++ {
+		for _, month := range []time.Month{time.January, time.July} {
+			t := time.Date(year, month, 1, 12, 0, 0, 0, time.UTC)
+			_, aOffset := t.In(a).Zone()
+			_, bOffset := t.In(b).Zone()
+			if aOffset != bOffset {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// formatDates formats each date as an ISO 8601 calendar date, on the date it falls in its own time zone
+func formatDates(dates []time.Time) []string {
+	var formatted []string
+	for _, date := range dates {
+		formatted = append(formatted, date.Format(dateLayout))
+	}
+	return formatted
+}
@@ -0,0 +1,351 @@
+package slachecker
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+const slaJSON = `{
+  "startTime": "2024-12-23T10:00:00Z",
+  "length": "P2D",
+  "schedule": {
+    "Monday": [{"start": "09:00", "end": "12:00"}, {"start": "13:00", "end": "17:30"}],
+    "tuesday": [{"start": "09:00", "end": "17:30"}],
+    "Wed": [{"start": "09:00", "end": "17:30"}],
+    "Thursday": [{"start": "09:00", "end": "17:30"}],
+    "Friday": [{"start": "09:00", "end": "17:00"}]
+  },
+  "holidays": ["2024-12-25", "2024-12-26"],
+  "reducedHours": [{"date": "2024-12-24", "hours": [{"start": "09:00", "end": "13:00"}]}],
+  "closures": [{"start": "2024-12-30T12:00:00Z", "end": "2024-12-31T12:00:00Z"}],
+  "location": "Europe/London",
+  "countryCode": "GB",
+  "pauses": [{"start": "2024-12-23T15:00:00Z", "end": "2024-12-23T16:00:00Z"}, {"start": "2024-12-27T10:00:00Z"}],
+  "atRisk": {"percentConsumed": 75, "remaining": "30m"}
+}`
+
+const slaYAML = `
+startTime: 2024-12-23T10:00:00Z
+length: P2D
+schedule:
+  Monday:
+    - {start: "09:00", end: "12:00"}
+    - {start: "13:00", end: "17:30"}
+  tuesday: [{start: "09:00", end: "17:30"}]
+  Wed: [{start: "09:00", end: "17:30"}]
+  Thursday: [{start: "09:00", end: "17:30"}]
+  Friday: [{start: "09:00", end: "17:00"}]
+holidays: [2024-12-25, 2024-12-26]
+reducedHours:
+  - date: 2024-12-24
+    hours: [{start: "09:00", end: "13:00"}]
+closures:
+  - {start: 2024-12-30T12:00:00Z, end: 2024-12-31T12:00:00Z}
+location: Europe/London
+countryCode: GB
+pauses:
+  - {start: 2024-12-23T15:00:00Z, end: 2024-12-23T16:00:00Z}
+  - start: 2024-12-27T10:00:00Z
+atRisk: {percentConsumed: 75, remaining: 30m}
+`
+
+func TestUnmarshalSLAConfig(t *testing.T) {
+	var fromJSON, fromYAML SLA
+	if err := json.Unmarshal([]byte(slaJSON), &fromJSON); err != nil {
+		t.Fatalf("Unexpected error decoding JSON: %v", err)
+	}
+	if err := yaml.Unmarshal([]byte(slaYAML), &fromYAML); err != nil {
+		t.Fatalf("Unexpected error decoding YAML: %v", err)
+	}
+
+	for name, sla := range map[string]SLA{"JSON": fromJSON, "YAML": fromYAML} {
+		if err := sla.Validate(); err != nil {
+			t.Fatalf("%s: unexpected validation error: %v", name, err)
+		}
+		if sla.Location == nil || sla.Location.String() != "Europe/London" {
+			t.Errorf("%s: expected location %q, but got %v", name, "Europe/London", sla.Location)
+		}
+		if expected := []BusinessHours{{Start: NewTimeOfDay(9, 0), End: NewTimeOfDay(17, 30)}}; !reflect.DeepEqual(sla.Schedule[time.Tuesday], expected) {
+			t.Errorf("%s: expected Tuesday hours %v, but got %v", name, expected, sla.Schedule[time.Tuesday])
+		}
+		if len(sla.Schedule[time.Wednesday]) != 1 {
+			t.Errorf("%s: expected Wednesday to be open, but got %v", name, sla.Schedule)
+		}
+		if expected := time.Date(2024, time.December, 25, 0, 0, 0, 0, time.UTC); len(sla.Holidays) != 2 || !sla.Holidays[0].Equal(expected) {
+			t.Errorf("%s: expected holidays from %v, but got %v", name, expected, sla.Holidays)
+		}
+		if len(sla.Pauses) != 2 || !sla.Pauses[1].End.IsZero() {
+			t.Errorf("%s: expected a closed and an open pause, but got %v", name, sla.Pauses)
+		}
+		if expected := (AtRiskThreshold{PercentConsumed: 75, Remaining: 30 * time.Minute}); sla.AtRisk != expected {
+			t.Errorf("%s: expected at-risk threshold %v, but got %v", name, expected, sla.AtRisk)
+		}
+		if sla.CountryCode != "GB" {
+			t.Errorf("%s: expected country code %q, but got %q", name, "GB", sla.CountryCode)
+		}
+	}
+
+	// Both formats describe the same SLA, so they give the same deadline
+	currentTime := time.Date(2024, time.December, 27, 12, 0, 0, 0, time.UTC)
+	jsonDeadline, err := fromJSON.Deadline(currentTime)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	yamlDeadline, err := fromYAML.Deadline(currentTime)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !jsonDeadline.Equal(yamlDeadline) {
+		t.Errorf("Expected the same deadline from JSON and YAML, but got %v and %v", jsonDeadline, yamlDeadline)
+	}
+}
+
+func TestSLAConfigRoundTrip(t *testing.T) {
+	var sla SLA
+	if err := json.Unmarshal([]byte(slaJSON), &sla); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	sla.BusinessWindows = []BusinessHours{{Start: NewTimeOfDay(22, 0), End: NewTimeOfDay(6, 0)}}
+	sla.ValidDays = []time.Weekday{time.Saturday, time.Sunday}
+	sla.ExtraHours = []DayHours{{Date: time.Date(2024, time.December, 28, 0, 0, 0, 0, time.UTC), Hours: sla.Schedule[time.Friday]}}
+	sla.CompletedAt = time.Date(2024, time.December, 31, 9, 0, 0, 0, time.UTC)
+	sla.ClockMode = ClockModeCalendarSkipHolidays
+	sla.Calendar = setupCalendar()
+	sla.Clock = NewFakeClock(sla.CompletedAt)
+
+	data, err := json.Marshal(sla)
+	if err != nil {
+		t.Fatalf("Unexpected error encoding JSON: %v", err)
+	}
+	var fromJSON SLA
+	if err := json.Unmarshal(data, &fromJSON); err != nil {
+		t.Fatalf("Unexpected error decoding JSON: %v", err)
+	}
+
+	data, err = yaml.Marshal(sla)
+	if err != nil {
+		t.Fatalf("Unexpected error encoding YAML: %v", err)
+	}
+	var fromYAML SLA
+	if err := yaml.Unmarshal(data, &fromYAML); err != nil {
+		t.Fatalf("Unexpected error decoding YAML: %v", err)
+	}
+
+	// The clock is not part of the configuration
+	sla.Clock = nil
+	expected, _ := newSLAConfig(sla)
+	for name, decoded := range map[string]SLA{"JSON": fromJSON, "YAML": fromYAML} {
+		if config, _ := newSLAConfig(decoded); !reflect.DeepEqual(config, expected) {
+			t.Errorf("%s: expected %+v after a round trip, but got %+v", name, expected, config)
+		}
+		if !decoded.StartTime.Equal(sla.StartTime) || !decoded.CompletedAt.Equal(sla.CompletedAt) {
+			t.Errorf("%s: expected start %v and completion %v, but got %v and %v", name, sla.StartTime, sla.CompletedAt, decoded.StartTime, decoded.CompletedAt)
+		}
+		if !reflect.DeepEqual(decoded.Calendar.ValidDays, sla.Calendar.ValidDays) || len(decoded.Calendar.Holidays) != len(sla.Calendar.Holidays) {
+			t.Errorf("%s: expected calendar %+v, but got %+v", name, sla.Calendar, decoded.Calendar)
+		}
+	}
+}
+
+func TestUnmarshalSLAConfigErrors(t *testing.T) {
+	tests := []struct {
+		name          string
+		json          string
+		expectedErr   error
+		expectedField string
+	}{
+		{name: "weekday", json: `{"validDays": ["Monday", "Funday"]}`, expectedErr: ErrInvalidDay, expectedField: "validDays[1]"},
+		{name: "schedule weekday", json: `{"schedule": {"Caturday": []}}`, expectedErr: ErrInvalidDay, expectedField: "schedule.Caturday"},
+		{
+			name:          "schedule time",
+			json:          `{"schedule": {"Monday": [{"start": "09:00", "end": "5pm"}]}}`,
+			expectedErr:   ErrInvalidBusinessHours,
+			expectedField: "schedule.Monday[0].end",
+		},
+		{name: "business hours", json: `{"businessHours": {"start": "9", "end": "17:00"}}`, expectedErr: ErrInvalidBusinessHours, expectedField: "businessHours.start"},
+		{name: "holiday", json: `{"holidays": ["2024-12-25", "25/12/2024"]}`, expectedErr: ErrInvalidHoliday, expectedField: "holidays[1]"},
+		{
+			name:          "reduced hours date",
+			json:          `{"reducedHours": [{"date": "Christmas Eve", "hours": [{"start": "09:00", "end": "13:00"}]}]}`,
+			expectedErr:   ErrInvalidHoliday,
+			expectedField: "reducedHours[0].date",
+		},
+		{
+			name:          "extra hours time",
+			json:          `{"extraHours": [{"date": "2024-12-28", "hours": [{"start": "09:00", "end": "25:00"}]}]}`,
+			expectedErr:   ErrInvalidBusinessHours,
+			expectedField: "extraHours[0].hours[0].end",
+		},
+		{name: "location", json: `{"location": "Europe/Atlantis"}`, expectedErr: ErrInvalidLocation, expectedField: "location"},
+		{name: "at-risk remaining", json: `{"atRisk": {"remaining": "soon"}}`, expectedErr: ErrInvalidDuration, expectedField: "atRisk.remaining"},
+		{name: "shared calendar", json: `{"calendar": {"validDays": ["Someday"]}}`, expectedErr: ErrInvalidDay, expectedField: "calendar.validDays[0]"},
+
+		// Failures caught by Validate are reported against the same paths
+		{name: "length", json: `{"slaLength": -1, "timeUnit": "hours", "validDays": ["Mon"]}`, expectedErr: ErrInvalidLength, expectedField: "slaLength"},
+		{
+			name:          "overlapping windows",
+			json:          `{"length": "PT4H", "businessWindows": [{"start": "09:00", "end": "13:00"}, {"start": "12:00", "end": "17:00"}], "validDays": ["Mon"]}`,
+			expectedErr:   ErrOverlappingWindows,
+			expectedField: "businessWindows",
+		},
+		{
+			name:          "schedule windows",
+			json:          `{"length": "PT4H", "schedule": {"Monday": [{"start": "09:00", "end": "13:00"}, {"start": "12:00", "end": "17:00"}]}}`,
+			expectedErr:   ErrOverlappingWindows,
+			expectedField: "schedule.Monday",
+		},
+		{name: "clock mode", json: `{"clockMode": "sometimes"}`, expectedErr: ErrInvalidClockMode, expectedField: "clockMode"},
+		{
+			name:          "closure",
+			json:          `{"length": "PT4H", "clockMode": "calendar", "closures": [{"start": "2024-12-30T12:00:00Z"}]}`,
+			expectedErr:   ErrInvalidClosure,
+			expectedField: "closures[0]",
+		},
+		{
+			name:          "reduced hours",
+			json:          `{"length": "PT4H", "clockMode": "calendar", "reducedHours": [{"date": "2024-12-24", "hours": []}]}`,
+			expectedErr:   ErrInvalidBusinessHours,
+			expectedField: "reducedHours[0].hours",
+		},
+		{name: "shared calendar days", json: `{"length": "PT4H", "calendar": {"businessHours": {"start": "09:00", "end": "17:00"}}}`, expectedErr: ErrNoValidDays, expectedField: "calendar.validDays"},
+		{
+			name:          "pause",
+			json:          `{"length": "PT4H", "clockMode": "calendar", "pauses": [{"start": "2024-12-23T15:00:00Z", "end": "2024-12-23T14:00:00Z"}]}`,
+			expectedErr:   ErrInvalidPause,
+			expectedField: "pauses[0]",
+		},
+		{name: "at-risk threshold", json: `{"length": "PT4H", "clockMode": "calendar", "atRisk": {"percentConsumed": 150}}`, expectedErr: ErrInvalidThreshold, expectedField: "atRisk.percentConsumed"},
+	}
+
+	for _, test := range tests {
+		var sla SLA
+		err := json.Unmarshal([]byte(test.json), &sla)
+		if !errors.Is(err, test.expectedErr) {
+			t.Errorf("%s: expected error %v, but got %v", test.name, test.expectedErr, err)
+			continue
+		}
+
+		var validationErr *ValidationError
+		if !errors.As(err, &validationErr) {
+			t.Errorf("%s: expected a *ValidationError, but got %T", test.name, err)
+			continue
+		}
+		if validationErr.Field != test.expectedField {
+			t.Errorf("%s: expected field %q, but got %q", test.name, test.expectedField, validationErr.Field)
+		}
+	}
+
+	// A Policy's SLA may leave out its length
+	var policy Policy
+	if err := json.Unmarshal([]byte(`{"SLA": {"clockMode": "calendar"}}`), &policy); err != nil {
+		t.Errorf("Expected an SLA without a length to decode, but got %v", err)
+	}
+
+	// YAML reports the same fields
+	var sla SLA
+	err := yaml.Unmarshal([]byte("schedule:\n  Monday: [{start: \"09:00\", end: \"5pm\"}]\n"), &sla)
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) || validationErr.Field != "schedule.Monday[0].end" {
+		t.Errorf("Expected a *ValidationError for %q, but got %v", "schedule.Monday[0].end", err)
+	}
+}
+
+func TestCalendarConfigRoundTrip(t *testing.T) {
+	calendar := setupCalendar()
+	data, err := json.Marshal(calendar)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := `{"businessHours":{"start":"09:00","end":"17:00"},"validDays":["Monday","Tuesday","Wednesday","Thursday","Friday"],"holidays":["2024-08-26"]}`
+	if string(data) != expected {
+		t.Errorf("Expected JSON %s, but got %s", expected, data)
+	}
+
+	decoded := &Calendar{}
+	if err := yaml.Unmarshal([]byte("businessHours: {start: \"09:00\", end: \"17:00\"}\nvalidDays: [Mon, Tue, Wed, Thu, Fri]\nholidays: [2024-08-26]\n"), decoded); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	start := time.Date(2024, time.August, 23, 16, 0, 0, 0, time.UTC)
+	expectedDeadline, _ := calendar.AddBusinessTime(start, 4*time.Hour)
+	deadline, err := decoded.AddBusinessTime(start, 4*time.Hour)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !deadline.Equal(expectedDeadline) {
+		t.Errorf("Expected the decoded calendar to give %v, but got %v", expectedDeadline, deadline)
+	}
+}
+
+func TestMarshalLocation(t *testing.T) {
+	london, err := time.LoadLocation("Europe/London")
+	if err != nil {
+		t.Fatalf("Error loading location: %v", err)
+	}
+
+	tests := []struct {
+		name        string
+		location    *time.Location
+		expectedErr error
+	}{
+		{name: "default"},
+		{name: "UTC", location: time.UTC},
+		{name: "IANA zone", location: london},
+		{name: "fixed UTC", location: time.FixedZone("UTC", 0)},
+		{name: "unnamed fixed zone", location: time.FixedZone("", 3600), expectedErr: ErrInvalidLocation},
+		{name: "fixed zone named like an IANA zone", location: time.FixedZone("CET", 3600), expectedErr: ErrInvalidLocation},
+	}
+
+	for _, test := range tests {
+		calendar := setupCalendar()
+		calendar.Location = test.location
+
+		_, jsonErr := json.Marshal(calendar)
+		_, yamlErr := yaml.Marshal(calendar)
+		_, slaErr := json.Marshal(SLA{StartTime: time.Now(), Length: "PT1H", Calendar: calendar})
+		for format, err := range map[string]error{"JSON": jsonErr, "YAML": yamlErr, "SLA": slaErr} {
+			if !errors.Is(err, test.expectedErr) {
+				t.Errorf("%s: expected %s error %v, but got %v", test.name, format, test.expectedErr, err)
+			}
+		}
+
+		var validationErr *ValidationError
+		if test.expectedErr != nil && (!errors.As(slaErr, &validationErr) || validationErr.Field != "calendar.location") {
+			t.Errorf("%s: expected a *ValidationError for %q, but got %v", test.name, "calendar.location", slaErr)
+		}
+	}
+}
+
+func BenchmarkMarshalSLA(b *testing.B) {
+	london, err := time.LoadLocation("Europe/London")
+	if err != nil {
+		b.Fatalf("Error loading location: %v", err)
+	}
+	// Decoding shares one cached location per zone name
+	decoded, err := loadLocation("Europe/London")
+	if err != nil {
+		b.Fatalf("Error loading location: %v", err)
+	}
+
+	for _, benchmark := range []struct {
+		name     string
+		location *time.Location
+	}{
+		{name: "loaded", location: london},
+		{name: "decoded", location: decoded},
+	} {
+		b.Run(benchmark.name, func(b *testing.B) {
+			sla := SLA{Length: "PT4H", Location: benchmark.location}
+			for i := 0; i < b.N; i++ {
+				if _, err := json.Marshal(sla); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
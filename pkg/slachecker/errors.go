@@ -0,0 +1,66 @@
+package slachecker
+
+import (
+	"errors"
+	"strings"
+)
+
+// Sentinel errors reported by Validate and the evaluation functions. Validation failures are
+// wrapped in a *ValidationError naming the offending field, so test for them with errors.Is.
+var (
+	ErrInvalidLength        = errors.New("SLA length must be greater than zero")
+	ErrInvalidTimeUnit      = errors.New("invalid time unit")
+	ErrInvalidDuration      = errors.New("invalid duration")
+	ErrInvalidClockMode     = errors.New("invalid clock mode")
+	ErrInvalidBusinessHours = errors.New("invalid business hours")
+	ErrOverlappingWindows   = errors.New("business windows overlap")
+	ErrNoValidDays          = errors.New("valid days cannot be empty")
+	ErrInvalidDay           = errors.New("invalid day")
+	ErrInvalidHoliday       = errors.New("invalid holiday date")
+	ErrInvalidLocation      = errors.New("invalid location")
+	ErrInvalidCountryCode   = errors.New("invalid country code")
+	ErrInvalidPause         = errors.New("invalid pause")
+	ErrInvalidClosure       = errors.New("invalid closure")
+	ErrNegativeDuration     = errors.New("business time cannot be negative")
+	ErrInvalidCompletion    = errors.New("completion cannot be before the start")
+	ErrInvalidThreshold     = errors.New("invalid at-risk threshold")
+	ErrNoTargets            = errors.New("policy must have at least one target")
+	ErrInvalidTarget        = errors.New("invalid target")
+	ErrUncoveredPriority    = errors.New("priority has no SLA")
+	ErrInvalidPriority      = errors.New("invalid priority")
+	ErrInvalidRule          = errors.New("invalid priority rule")
+)
+
+// ValidationError reports which field of an SLA failed validation
+type ValidationError struct {
+	Field string // Path to the offending field, e.g. "BusinessHours" or "Schedule[Monday]"
+	Err   error  // One of the Err sentinel errors, possibly wrapped with more detail
+}
+
+// Error formats the error as "field: reason"
+func (e *ValidationError) Error() string {
+	return e.Field + ": " + e.Err.Error()
+}
+
+// Unwrap returns the underlying error, so errors.Is can match the sentinel errors
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
+
+// withField reports err against the given field. When err is already a *ValidationError its
+// field is treated as relative to the given one, e.g. "Schedule" and "[Monday]" become "Schedule[Monday]".
+func withField(field string, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		return &ValidationError{Field: field, Err: err}
+	}
+
+	if strings.HasPrefix(validationErr.Field, "[") {
+		return &ValidationError{Field: field + validationErr.Field, Err: validationErr.Err}
+	}
+	return &ValidationError{Field: field + "." + validationErr.Field, Err: validationErr.Err}
+}
@@ -0,0 +1,46 @@
+package slachecker
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestWithField(t *testing.T) {
+	tests := []struct {
+		name          string
+		field         string
+		err           error
+		expectedField string
+		expectedErr   error
+	}{
+		{name: "plain error", field: "BusinessHours", err: ErrInvalidBusinessHours, expectedField: "BusinessHours", expectedErr: ErrInvalidBusinessHours},
+		{name: "indexed field", field: "Schedule", err: &ValidationError{Field: "[Monday]", Err: ErrOverlappingWindows}, expectedField: "Schedule[Monday]", expectedErr: ErrOverlappingWindows},
+		{name: "nested field", field: "Calendar", err: &ValidationError{Field: "ValidDays", Err: ErrNoValidDays}, expectedField: "Calendar.ValidDays", expectedErr: ErrNoValidDays},
+	}
+
+	for _, test := range tests {
+		err := withField(test.field, test.err)
+
+		var validationErr *ValidationError
+		if !errors.As(err, &validationErr) {
+			t.Fatalf("%s: expected a *ValidationError, but got %T", test.name, err)
+		}
+		if validationErr.Field != test.expectedField {
+			t.Errorf("%s: expected field %q, but got %q", test.name, test.expectedField, validationErr.Field)
+		}
+		if !errors.Is(err, test.expectedErr) {
+			t.Errorf("%s: expected error to wrap %v", test.name, test.expectedErr)
+		}
+	}
+
+	if err := withField("SLALength", nil); err != nil {
+		t.Errorf("Expected no error, but got %v", err)
+	}
+}
+
+func TestValidationErrorMessage(t *testing.T) {
+	err := &ValidationError{Field: "ValidDays", Err: ErrNoValidDays}
+	if err.Error() != "ValidDays: valid days cannot be empty" {
+		t.Errorf("Unexpected error message: %s", err.Error())
+	}
+}
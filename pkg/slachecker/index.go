@@ -0,0 +1,278 @@
+package slachecker
+
+import (
+	"sort"
+	"time"
+)
+
+// civilDate is a calendar date without a time of day or time zone
+type civilDate struct {
+	year  int
+	month time.Month
+	day   int
+}
+
+// dateOf returns the calendar date of t in its own time zone
+func dateOf(t time.Time) civilDate {
+	year, month, day := t.Date()
+	return civilDate{year: year, month: month, day: day}
+}
+
+// calendarDates is the schedule and dated entries of a calendar compiled for constant-time lookups
+type calendarDates struct {
+	weekly       [7][]BusinessHours            // Windows for each weekday, ordered by opening time
+	holidays     map[civilDate]bool            // Dates the business is closed
+	reducedHours map[civilDate][]BusinessHours // Windows replacing the weekly ones, ordered by opening time
+	extraHours   map[civilDate][]BusinessHours // Windows added to the usual ones
+}
+
+// yearIndex holds the business intervals opening in one calendar year, ordered by opening time,
+// with the business time in the intervals before each of them
+type yearIndex struct {
+	intervals []interval
+	before    []time.Duration // before[i] is the business time in intervals[:i], so it has one more entry than intervals
+}
+
+// compiledDates returns the calendar's dates compiled for constant-time lookups, building them on first use
+func (c *Calendar) compiledDates() *calendarDates {
+	c.datesOnce.Do(func() {
+		dates := &calendarDates{
+			holidays:     make(map[civilDate]bool, len(c.Holidays)),
+			reducedHours: make(map[civilDate][]BusinessHours, len(c.ReducedHours)),
+			extraHours:   make(map[civilDate][]BusinessHours, len(c.ExtraHours)),
+		}
+
+		schedule := c.schedule()
+		for day := time.Sunday; day <= time.Saturday; day++ {
+			dates.weekly[day] = schedule.windowsOn(day)
+		}
+		for _, holiday := range c.Holidays {
+			dates.holidays[dateOf(holiday)] = true
+		}
+		for _, dayHours := range c.ReducedHours {
+			// The first reduced hours given for a date apply
+			if _, ok := dates.reducedHours[dateOf(dayHours.Date)]; !ok {
+				dates.reducedHours[dateOf(dayHours.Date)] = sortWindows(dayHours.Hours)
+			}
+		}
+		for _, dayHours := range c.ExtraHours {
+			dates.extraHours[dateOf(dayHours.Date)] = append(dates.extraHours[dateOf(dayHours.Date)], dayHours.Hours...)
+		}
+		c.dates = dates
+	})
+	return c.dates
+}
+
+// yearIndex returns the business intervals opening in the given year, building them on first use
+func (c *Calendar) yearIndex(year int) *yearIndex {
+	c.mu.RLock()
+	index, ok := c.years[year]
+	c.mu.RUnlock()
+	if ok {
+		return index
+	}
+
+	// Build the index outside the lock; if another goroutine builds the same year first, keep theirs
+	index = c.buildYearIndex(year)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if existing, ok := c.years[year]; ok {
+		return existing
+	}
+	if c.years == nil {
+		c.years = make(map[int]*yearIndex)
+	}
+	c.years[year] = index
+	return index
+}
+
+// buildYearIndex collects the business intervals of every day of the year and sums the business time before each
+func (c *Calendar) buildYearIndex(year int) *yearIndex {
+	day := time.Date(year, time.January, 1, 12, 0, 0, 0, c.location())
+
+	// An overnight window opening on the last day of the previous year belongs to that year's index,
+	// so any extra hours overlapping it are only counted from its closing time
+	var intervals []interval
+	for day.Year() == year {
+		intervals = append(intervals, c.businessIntervals(day)...)
+		day = civilDay(day, 1)
+	}
+	for _, previous := range c.businessIntervals(time.Date(year-1, time.December, 31, 12, 0, 0, 0, c.location())) {
+		intervals = withoutClosure(intervals, Closure{Start: previous.start, End: previous.end})
+	}
+
+	// Extra hours may run into an overnight window from the day before, so join them across days too
+	index := &yearIndex{}
+	if len(intervals) > 0 {
+		index.intervals = mergeIntervals(intervals)
+	}
+
+	index.before = make([]time.Duration, len(index.intervals)+1)
+	for i, businessInterval := range index.intervals {
+		index.before[i+1] = index.before[i] + businessInterval.end.Sub(businessInterval.start)
+	}
+	return index
+}
+
+// total returns the business time in all of the year's intervals
+func (y *yearIndex) total() time.Duration {
+	return y.before[len(y.intervals)]
+}
+
+// businessTimeBefore returns the business time in the year's intervals before t
+func (y *yearIndex) businessTimeBefore(t time.Time) time.Duration {
+	i := sort.Search(len(y.intervals), func(i int) bool { return y.intervals[i].end.After(t) })
+	if i < len(y.intervals) && t.After(y.intervals[i].start) {
+		return y.before[i] + t.Sub(y.intervals[i].start)
+	}
+	return y.before[i]
+}
+
+// closingAt returns the earliest instant by which the year's intervals hold the given business time,
+// which is a closing time when the business time runs out exactly at the end of an interval
+func (y *yearIndex) closingAt(businessTime time.Duration) time.Time {
+	i := sort.Search(len(y.intervals), func(i int) bool { return y.before[i+1] >= businessTime })
+	return y.intervals[i].start.Add(businessTime - y.before[i])
+}
+
+// openingAt returns the latest instant by which the year's intervals hold the given business time,
+// which is an opening time when the business time runs out exactly at the end of an interval
+func (y *yearIndex) openingAt(businessTime time.Duration) time.Time {
+	i := sort.Search(len(y.intervals), func(i int) bool { return y.before[i+1] > businessTime })
+	return y.intervals[i].start.Add(businessTime - y.before[i])
+}
+
+// indexedNextInterval returns the business interval containing t, or the next one to open after t
+func (c *Calendar) indexedNextInterval(t time.Time) (time.Time, time.Time) {
+	t = t.In(c.location())
+
+	// Start from the previous year, whose last overnight window may still be open
+	for year := t.Year() - 1; ; year++ {
+		index := c.yearIndex(year)
+		i := sort.Search(len(index.intervals), func(i int) bool { return index.intervals[i].end.After(t) })
+		if i == len(index.intervals) {
+			continue
+		}
+		if t.Before(index.intervals[i].start) {
+			return index.intervals[i].start, index.intervals[i].end
+		}
+		return t, index.intervals[i].end
+	}
+}
+
+// indexedPreviousInterval returns the business interval containing t, or the last one to close before t
+func (c *Calendar) indexedPreviousInterval(t time.Time) (time.Time, time.Time) {
+	t = t.In(c.location())
+
+	for year := t.Year(); ; year-- {
+		index := c.yearIndex(year)
+		i := sort.Search(len(index.intervals), func(i int) bool { return !index.intervals[i].start.Before(t) }) - 1
+		if i < 0 {
+			continue
+		}
+		if index.intervals[i].end.After(t) {
+			return index.intervals[i].start, t
+		}
+		return index.intervals[i].start, index.intervals[i].end
+	}
+}
+
+// indexedBusinessTime returns the business time between from and to, or zero when to is not after from
+func (c *Calendar) indexedBusinessTime(from, to time.Time) time.Duration {
+	if !from.Before(to) {
+		return 0
+	}
+
+	// Intervals opening in the year before from may still be open
+	total := time.Duration(0)
+	for year := from.In(c.location()).Year() - 1; year <= to.In(c.location()).Year(); year++ {
+		index := c.yearIndex(year)
+		total += index.businessTimeBefore(to) - index.businessTimeBefore(from)
+	}
+	return total
+}
+
+// indexedAdd returns the instant at which the given amount of business time has passed after t
+func (c *Calendar) indexedAdd(t time.Time, d time.Duration) time.Time {
+	if d <= 0 {
+		return t
+	}
+
+	remaining := d
+	for year := t.In(c.location()).Year() - 1; ; year++ {
+		index := c.yearIndex(year)
+		used := index.businessTimeBefore(t)
+		if available := index.total() - used; remaining > available {
+			remaining -= available
+			continue
+		}
+		return index.closingAt(used + remaining)
+	}
+}
+
+// indexedSubtract returns the instant that lies the given amount of business time before t
+func (c *Calendar) indexedSubtract(t time.Time, d time.Duration) time.Time {
+	if d <= 0 {
+		return t
+	}
+
+	remaining := d
+	for year := t.In(c.location()).Year(); ; year-- {
+		index := c.yearIndex(year)
+		available := index.businessTimeBefore(t)
+		if remaining > available {
+			remaining -= available
+			continue
+		}
+		return index.openingAt(available - remaining)
+	}
+}
+
+// indexedRunningTime returns the business time between from and to that is not covered by the pauses
+func (c *Calendar) indexedRunningTime(from, to time.Time, pauses []interval) time.Duration {
+	total := c.indexedBusinessTime(from, to)
+	return total - c.pausedBusinessTime(from, to, pauses)
+}
+
+// indexedAddRunning returns the instant at which the given amount of running clock time has passed after t.
+// The business time paused on the way to a candidate deadline pushes it back, which can run into further
+// pauses, so the deadline is extended until no more paused time is found.
+func (c *Calendar) indexedAddRunning(t time.Time, d time.Duration, pauses []interval) time.Time {
+	if d <= 0 {
+		return t
+	}
+
+	deadline := c.indexedAdd(t, d)
+	for {
+		paused := c.pausedBusinessTime(t, deadline, pauses)
+		extended := c.indexedAdd(t, d+paused)
+		if extended.Equal(deadline) {
+			return deadline
+		}
+		deadline = extended
+	}
+}
+
+// pausedBusinessTime returns the business time between from and to covered by at least one of the pauses
+func (c *Calendar) pausedBusinessTime(from, to time.Time, pauses []interval) time.Duration {
+	if len(pauses) == 0 {
+		return 0
+	}
+
+	// Pauses may overlap, so join them before measuring
+	merged := mergeIntervals(append([]interval(nil), pauses...))
+
+	paused := time.Duration(0)
+	for _, pause := range merged {
+		start, end := pause.start, pause.end
+		if start.Before(from) {
+			start = from
+		}
+		if end.After(to) {
+			end = to
+		}
+		paused += c.indexedBusinessTime(start, end)
+	}
+	return paused
+}
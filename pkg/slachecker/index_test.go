@@ -0,0 +1,345 @@
+package slachecker
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+// setupIndexCalendar returns a calendar using every kind of window and dated entry, indexed unless singleUse is set
+func setupIndexCalendar(location *time.Location, singleUse bool) *Calendar {
+	date := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 0, 0, 0, 0, location)
+	}
+
+	return &Calendar{
+		Schedule: WeeklySchedule{
+			time.Monday:    {{Start: NewTimeOfDay(9, 0), End: NewTimeOfDay(12, 0)}, {Start: NewTimeOfDay(13, 0), End: NewTimeOfDay(17, 30)}},
+			time.Tuesday:   {{Start: NewTimeOfDay(9, 0), End: NewTimeOfDay(17, 30)}},
+			time.Wednesday: {{Start: NewTimeOfDay(9, 0), End: NewTimeOfDay(17, 30)}},
+			time.Thursday:  {{Start: NewTimeOfDay(9, 0), End: NewTimeOfDay(17, 30)}},
+			time.Friday:    {{Start: NewTimeOfDay(9, 0), End: NewTimeOfDay(17, 0)}, {Start: NewTimeOfDay(22, 0), End: NewTimeOfDay(2, 0)}},
+		},
+		Holidays: []time.Time{
+			date(2024, time.December, 25),
+			date(2024, time.December, 26),
+			date(2025, time.January, 1),
+			date(2025, time.April, 18),
+			date(2025, time.April, 21),
+		},
+		ReducedHours: []DayHours{
+			{Date: date(2024, time.December, 24), Hours: []BusinessHours{{Start: NewTimeOfDay(9, 0), End: NewTimeOfDay(13, 0)}}},
+		},
+		ExtraHours: []DayHours{
+			{Date: date(2024, time.December, 28), Hours: []BusinessHours{{Start: NewTimeOfDay(1, 0), End: NewTimeOfDay(12, 0)}}},
+			{Date: date(2025, time.January, 1), Hours: []BusinessHours{{Start: NewTimeOfDay(10, 0), End: NewTimeOfDay(14, 0)}}},
+		},
+		Closures: []Closure{
+			{Start: time.Date(2025, time.February, 12, 15, 0, 0, 0, location), End: time.Date(2025, time.February, 17, 11, 0, 0, 0, location)},
+		},
+		Location:  location,
+		singleUse: singleUse,
+	}
+}
+
+func TestIndexedCalendarMatchesWalk(t *testing.T) {
+	london, err := time.LoadLocation("Europe/London")
+	if err != nil {
+		t.Fatalf("Error loading location: %v", err)
+	}
+	santiago, err := time.LoadLocation("America/Santiago")
+	if err != nil {
+		t.Fatalf("Error loading location: %v", err)
+	}
+
+	// From mid-December to the end of March, across the new year and a DST change
+	testIndexedCalendarMatchesWalk(t, london, time.Date(2024, time.December, 15, 0, 0, 0, 0, london), time.Date(2025, time.March, 31, 0, 0, 0, 0, london))
+	// Across the clocks going forward at midnight on 8 September 2024, so that midnight never happens
+	testIndexedCalendarMatchesWalk(t, santiago, time.Date(2024, time.August, 15, 0, 0, 0, 0, santiago), time.Date(2024, time.October, 1, 0, 0, 0, 0, santiago))
+}
+
+// testIndexedCalendarMatchesWalk checks the indexed and walked calendars agree every six hours and seventeen minutes from one time to another
+func testIndexedCalendarMatchesWalk(t *testing.T, location *time.Location, from, to time.Time) {
+	indexed := setupIndexCalendar(location, false)
+	walked := setupIndexCalendar(location, true)
+	pauses := []interval{
+		{start: time.Date(2024, time.September, 6, 16, 0, 0, 0, location), end: time.Date(2024, time.September, 9, 10, 0, 0, 0, location)},
+		{start: time.Date(2024, time.December, 20, 16, 0, 0, 0, location), end: time.Date(2024, time.December, 23, 10, 0, 0, 0, location)},
+		{start: time.Date(2024, time.December, 23, 9, 30, 0, 0, location), end: time.Date(2024, time.December, 30, 12, 0, 0, 0, location)},
+		{start: time.Date(2025, time.March, 3, 9, 0, 0, 0, location), end: time.Date(2025, time.March, 3, 11, 0, 0, 0, location)},
+	}
+	durations := []time.Duration{time.Minute, 4 * time.Hour, 8*time.Hour + 30*time.Minute, 90 * time.Hour, 1000 * time.Hour}
+
+	for current := from; current.Before(to); current = current.Add(6*time.Hour + 17*time.Minute) {
+		name := current.Format(time.RFC3339)
+
+		// Extra hours running into an overnight window are joined with it in the index, so only compare
+		// the opening and closing times the calendar relies on
+		expectedOpening, _ := walked.nextBusinessInterval(current)
+		if opening, _ := indexed.nextBusinessInterval(current); !opening.Equal(expectedOpening) {
+			t.Errorf("%s: expected next opening %v, but got %v", name, expectedOpening, opening)
+		}
+		_, expectedClosing := walked.previousBusinessInterval(current)
+		if _, closing := indexed.previousBusinessInterval(current); !closing.Equal(expectedClosing) {
+			t.Errorf("%s: expected previous closing %v, but got %v", name, expectedClosing, closing)
+		}
+		if expected, got := walked.isOpen(current), indexed.isOpen(current); got != expected {
+			t.Errorf("%s: expected open to be %v, but got %v", name, expected, got)
+		}
+		if expected, got := walked.addDays(current, 7, 3), indexed.addDays(current, 7, 3); !got.Equal(expected) {
+			t.Errorf("%s: expected %v after adding days, but got %v", name, expected, got)
+		}
+		if expected, got := walked.subtractDays(current, 7, 3), indexed.subtractDays(current, 7, 3); !got.Equal(expected) {
+			t.Errorf("%s: expected %v after subtracting days, but got %v", name, expected, got)
+		}
+
+		for _, d := range durations {
+			caseName := fmt.Sprintf("%s plus %v", name, d)
+			if expected, got := walked.addRunningTime(current, d, nil), indexed.addRunningTime(current, d, nil); !got.Equal(expected) {
+				t.Errorf("%s: expected deadline %v, but got %v", caseName, expected, got)
+			}
+			if expected, got := walked.addRunningTime(current, d, pauses), indexed.addRunningTime(current, d, pauses); !got.Equal(expected) {
+				t.Errorf("%s with pauses: expected deadline %v, but got %v", caseName, expected, got)
+			}
+			if expected, got := walked.subtractBusinessTime(current, d), indexed.subtractBusinessTime(current, d); !got.Equal(expected) {
+				t.Errorf("%s: expected start %v, but got %v", caseName, expected, got)
+			}
+
+			end := current.Add(d)
+			if expected, got := walked.runningTimeBetween(current, end, nil), indexed.runningTimeBetween(current, end, nil); got != expected {
+				t.Errorf("%s: expected business time %v, but got %v", caseName, expected, got)
+			}
+			if expected, got := walked.runningTimeBetween(current, end, pauses), indexed.runningTimeBetween(current, end, pauses); got != expected {
+				t.Errorf("%s with pauses: expected running time %v, but got %v", caseName, expected, got)
+			}
+		}
+	}
+}
+
+func TestIndexedCalendarConcurrentUse(t *testing.T) {
+	calendar := setupCalendar()
+	start := time.Date(2024, time.August, 23, 10, 0, 0, 0, time.UTC)
+	expected := time.Date(2024, time.August, 28, 10, 0, 0, 0, time.UTC)
+
+	results := make(chan time.Time, 8)
+	for i := 0; i < cap(results); i++ {
+		go func() {
+			deadline, _ := calendar.AddBusinessTime(start, 16*time.Hour)
+			results <- deadline
+		}()
+	}
+	for i := 0; i < cap(results); i++ {
+		if deadline := <-results; !deadline.Equal(expected) {
+			t.Errorf("Expected deadline to be %v, but got %v", expected, deadline)
+		}
+	}
+}
+
+// setupBenchmarkCalendar returns a UK-style calendar with ten years of bank holidays
+func setupBenchmarkCalendar(singleUse bool) *Calendar {
+	var holidays []time.Time
+	for year := 2020; year < 2030; year++ {
+		for _, holiday := range []struct {
+			month time.Month
+			day   int
+		}{
+			{time.January, 1}, {time.April, 7}, {time.April, 10}, {time.May, 5}, {time.May, 26},
+			{time.August, 25}, {time.December, 25}, {time.December, 26},
+		} {
+			holidays = append(holidays, time.Date(year, holiday.month, holiday.day, 0, 0, 0, 0, time.UTC))
+		}
+	}
+
+	return &Calendar{
+		BusinessHours: BusinessHours{Start: NewTimeOfDay(9, 0), End: NewTimeOfDay(17, 0)},
+		ValidDays:     []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday},
+		Holidays:      holidays,
+		singleUse:     singleUse,
+	}
+}
+
+// linearBusinessIntervals returns the business intervals on day the way calendars found them before they were
+// indexed, scanning every holiday and rebuilding the weekly schedule for each day. It only handles the
+// business hours, valid days and holidays setupBenchmarkCalendar uses.
+func linearBusinessIntervals(c *Calendar, day time.Time) []interval {
+	for _, holiday := range c.Holidays {
+		if dateOf(holiday) == dateOf(day) {
+			return nil
+		}
+	}
+
+	var intervals []interval
+	year, month, date := day.Date()
+	for _, window := range c.schedule().windowsOn(day.Weekday()) {
+		intervals = append(intervals, interval{
+			start: window.Start.on(year, month, date, day.Location()),
+			end:   window.End.on(year, month, date, day.Location()),
+		})
+	}
+	return intervals
+}
+
+// linearAddBusinessTime adds business time by walking linearBusinessIntervals day by day
+func linearAddBusinessTime(c *Calendar, t time.Time, d time.Duration) time.Time {
+	t = t.In(c.location())
+	for day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location()); ; day = day.AddDate(0, 0, 1) {
+		for _, businessInterval := range linearBusinessIntervals(c, day) {
+			if !t.Before(businessInterval.end) {
+				continue
+			}
+			if t.Before(businessInterval.start) {
+				t = businessInterval.start
+			}
+			available := businessInterval.end.Sub(t)
+			if d <= available {
+				return t.Add(d)
+			}
+			d -= available
+			t = businessInterval.end
+		}
+	}
+}
+
+// linearBusinessTimeBetween sums business time by walking linearBusinessIntervals day by day
+func linearBusinessTimeBetween(c *Calendar, from, to time.Time) time.Duration {
+	from = from.In(c.location())
+	var total time.Duration
+	for day := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, from.Location()); day.Before(to); day = day.AddDate(0, 0, 1) {
+		for _, businessInterval := range linearBusinessIntervals(c, day) {
+			start, end := businessInterval.start, businessInterval.end
+			if start.Before(from) {
+				start = from
+			}
+			if end.After(to) {
+				end = to
+			}
+			if start.Before(end) {
+				total += end.Sub(start)
+			}
+		}
+	}
+	return total
+}
+
+// The "linear scan" benchmarks measure the day-by-day walk with a scan of every holiday that calendars
+// used before they were indexed, and "walk" the day-by-day walk SLAs with their own calendar fields still use
+
+func BenchmarkLongDeadline(b *testing.B) {
+	start := time.Date(2024, time.January, 15, 10, 0, 0, 0, time.UTC)
+	d := 720 * time.Hour // 90 business days of 8 hours
+	add := func(calendar *Calendar) time.Time {
+		deadline, _ := calendar.AddBusinessTime(start, d)
+		return deadline
+	}
+	expected := linearAddBusinessTime(setupBenchmarkCalendar(true), start, d)
+
+	for _, benchmark := range []struct {
+		name      string
+		singleUse bool
+		add       func(calendar *Calendar) time.Time
+	}{
+		{name: "indexed", add: add},
+		{name: "walk", singleUse: true, add: add},
+		{name: "linear scan", singleUse: true, add: func(calendar *Calendar) time.Time { return linearAddBusinessTime(calendar, start, d) }},
+	} {
+		b.Run(benchmark.name, func(b *testing.B) {
+			calendar := setupBenchmarkCalendar(benchmark.singleUse)
+			if deadline := benchmark.add(calendar); !deadline.Equal(expected) {
+				b.Fatalf("Expected deadline %v, but got %v", expected, deadline)
+			}
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				benchmark.add(calendar)
+			}
+		})
+	}
+}
+
+func BenchmarkBusinessTimeBetween(b *testing.B) {
+	from := time.Date(2021, time.March, 1, 12, 0, 0, 0, time.UTC)
+	to := time.Date(2028, time.October, 1, 12, 0, 0, 0, time.UTC)
+	between := func(calendar *Calendar) time.Duration {
+		businessTime, _ := calendar.BusinessTimeBetween(from, to)
+		return businessTime
+	}
+	expected := linearBusinessTimeBetween(setupBenchmarkCalendar(true), from, to)
+
+	for _, benchmark := range []struct {
+		name      string
+		singleUse bool
+		between   func(calendar *Calendar) time.Duration
+	}{
+		{name: "indexed", between: between},
+		{name: "walk", singleUse: true, between: between},
+		{name: "linear scan", singleUse: true, between: func(calendar *Calendar) time.Duration { return linearBusinessTimeBetween(calendar, from, to) }},
+	} {
+		b.Run(benchmark.name, func(b *testing.B) {
+			calendar := setupBenchmarkCalendar(benchmark.singleUse)
+			if businessTime := benchmark.between(calendar); businessTime != expected {
+				b.Fatalf("Expected business time %v, but got %v", expected, businessTime)
+			}
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				benchmark.between(calendar)
+			}
+		})
+	}
+}
+
+func BenchmarkIsOpen(b *testing.B) {
+	t := time.Date(2024, time.June, 3, 10, 0, 0, 0, time.UTC)
+	for _, count := range []int{10, 100000} {
+		b.Run(fmt.Sprintf("%d holidays", count), func(b *testing.B) {
+			// One holiday a day, long before t
+			calendar := setupBenchmarkCalendar(false)
+			calendar.Holidays = make([]time.Time, count)
+			for i := range calendar.Holidays {
+				calendar.Holidays[i] = time.Date(1700, time.January, 1+i, 0, 0, 0, 0, time.UTC)
+			}
+			if !calendar.IsOpen(t) {
+				b.Fatalf("Expected the calendar to be open at %v", t)
+			}
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				calendar.IsOpen(t)
+			}
+		})
+	}
+}
+
+func BenchmarkEvaluateCalendar(b *testing.B) {
+	start := time.Date(2024, time.August, 23, 10, 0, 0, 0, time.UTC)
+
+	// Holidays long before the start, which still have to be compiled for every inline evaluation
+	holidays := make([]time.Time, 3000)
+	for i := range holidays {
+		holidays[i] = time.Date(2000, time.January, 1+i, 0, 0, 0, 0, time.UTC)
+	}
+	shared := setupCalendar()
+	shared.Holidays = holidays
+
+	for _, benchmark := range []struct {
+		name string
+		sla  SLA
+	}{
+		{name: "shared", sla: SLA{StartTime: start, Length: "PT16H", Calendar: shared}},
+		{
+			name: "inline",
+			sla: SLA{
+				StartTime:     start,
+				Length:        "PT16H",
+				BusinessHours: shared.BusinessHours,
+				ValidDays:     shared.ValidDays,
+				Holidays:      holidays,
+			},
+		},
+	} {
+		b.Run(benchmark.name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := benchmark.sla.Evaluate(start.Add(time.Hour)); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,129 @@
+package slachecker
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// slaLength is an SLA length made up of calendar weeks, business days and business time, applied in that order
+type slaLength struct {
+	weeks        int           // Calendar weeks, landing on the same time of day
+	businessDays int           // Business days, landing on the same time of day
+	duration     time.Duration // Business time
+}
+
+// isZero reports whether the length adds no time at all
+func (l slaLength) isZero() bool {
+	return l.weeks == 0 && l.businessDays == 0 && l.duration == 0
+}
+
+// parseLength parses an ISO 8601 duration such as "PT1H30M", "P2D" or "P1W", or a Go duration such as "1h30m".
+// ISO 8601 days are business days and weeks are calendar weeks; years and months are rejected because
+// they have no fixed length.
+func parseLength(value string) (slaLength, error) {
+	var length slaLength
+	if strings.HasPrefix(value, "P") {
+		parsed, err := parseISODuration(value)
+		if err != nil {
+			return slaLength{}, fmt.Errorf("%w: %q: %v", ErrInvalidDuration, value, err)
+		}
+		length = parsed
+	} else {
+		duration, err := time.ParseDuration(value)
+		if err != nil {
+			return slaLength{}, fmt.Errorf("%w: %q is neither an ISO 8601 nor a Go duration", ErrInvalidDuration, value)
+		}
+		if duration < 0 {
+			return slaLength{}, fmt.Errorf("%w: got %q", ErrInvalidLength, value)
+		}
+		length.duration = duration
+	}
+
+	if length.isZero() {
+		return slaLength{}, fmt.Errorf("%w: got %q", ErrInvalidLength, value)
+	}
+	return length, nil
+}
+
+// parseISODuration parses an ISO 8601 duration of the form PnWnDTnHnMnS, where the hours, minutes
+// and seconds may have a fractional part
+func parseISODuration(value string) (slaLength, error) {
+	var length slaLength
+	datePart, timePart, hasTime := strings.Cut(strings.TrimPrefix(value, "P"), "T")
+	if datePart == "" && timePart == "" {
+		return slaLength{}, fmt.Errorf("no duration components")
+	}
+	if hasTime && timePart == "" {
+		return slaLength{}, fmt.Errorf("no time components after T")
+	}
+
+	err := eachComponent(datePart, "YMWD", func(number string, designator byte) error {
+		if designator == 'Y' || designator == 'M' {
+			return fmt.Errorf("years and months have no fixed length")
+		}
+		count, err := strconv.Atoi(number)
+		if errors.Is(err, strconv.ErrRange) || (designator == 'W' && count > math.MaxInt/7) {
+			return fmt.Errorf("%s%c is too large", number, designator)
+		}
+		if err != nil {
+			return fmt.Errorf("weeks and days must be whole numbers")
+		}
+		if designator == 'W' {
+			length.weeks = count
+		} else {
+			length.businessDays = count
+		}
+		return nil
+	})
+	if err != nil {
+		return slaLength{}, err
+	}
+
+	units := map[byte]time.Duration{'H': time.Hour, 'M': time.Minute, 'S': time.Second}
+	err = eachComponent(timePart, "HMS", func(number string, designator byte) error {
+		amount, err := strconv.ParseFloat(number, 64)
+		if err != nil {
+			return fmt.Errorf("invalid number %q", number)
+		}
+		// Like time.ParseDuration, reject anything that does not fit in a time.Duration
+		// rather than letting it wrap around to a negative length
+		amount *= float64(units[designator])
+		if amount >= float64(math.MaxInt64) || time.Duration(amount) > math.MaxInt64-length.duration {
+			return fmt.Errorf("%s%c is too large", number, designator)
+		}
+		length.duration += time.Duration(amount)
+		return nil
+	})
+	if err != nil {
+		return slaLength{}, err
+	}
+	return length, nil
+}
+
+// eachComponent calls fn with the number and designator of each component in part, e.g. "2" and 'D' for "2D".
+// Designators must be taken from order and appear at most once, in that order.
+func eachComponent(part, order string, fn func(number string, designator byte) error) error {
+	last := -1
+	for part != "" {
+		i := strings.IndexFunc(part, func(r rune) bool { return (r < '0' || r > '9') && r != '.' })
+		if i <= 0 {
+			return fmt.Errorf("expected a number followed by one of %s", order)
+		}
+
+		position := strings.IndexByte(order, part[i])
+		if position <= last {
+			return fmt.Errorf("unexpected designator %q", part[i])
+		}
+		last = position
+
+		if err := fn(part[:i], part[i]); err != nil {
+			return err
+		}
+		part = part[i+1:]
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package slachecker
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseLength(t *testing.T) {
+	tests := []struct {
+		value       string
+		expected    slaLength
+		expectedErr error
+	}{
+		{value: "PT1H30M", expected: slaLength{duration: 90 * time.Minute}},
+		{value: "PT45S", expected: slaLength{duration: 45 * time.Second}},
+		{value: "PT1.5H", expected: slaLength{duration: 90 * time.Minute}},
+		{value: "P2D", expected: slaLength{businessDays: 2}},
+		{value: "P1W", expected: slaLength{weeks: 1}},
+		{value: "P1W2DT4H", expected: slaLength{weeks: 1, businessDays: 2, duration: 4 * time.Hour}},
+		{value: "1h30m", expected: slaLength{duration: 90 * time.Minute}},
+		{value: "90s", expected: slaLength{duration: 90 * time.Second}},
+		{value: "P1Y", expectedErr: ErrInvalidDuration},
+		{value: "P1M", expectedErr: ErrInvalidDuration},
+		{value: "P1.5D", expectedErr: ErrInvalidDuration},
+		{value: "P", expectedErr: ErrInvalidDuration},
+		{value: "P1DT", expectedErr: ErrInvalidDuration},
+		{value: "PT1M1H", expectedErr: ErrInvalidDuration},
+		{value: "PTH", expectedErr: ErrInvalidDuration},
+		{value: "PT1X", expectedErr: ErrInvalidDuration},
+		{value: "four hours", expectedErr: ErrInvalidDuration},
+		{value: "PT0S", expectedErr: ErrInvalidLength},
+		{value: "-1h", expectedErr: ErrInvalidLength},
+		{value: "PT9999999999H", expectedErr: ErrInvalidDuration},
+		{value: "PT2562047H50M", expectedErr: ErrInvalidDuration},
+		{value: "P99999999999999999999D", expectedErr: ErrInvalidDuration},
+		{value: "P9999999999999999999W", expectedErr: ErrInvalidDuration},
+		{value: "P2000000000000000000W", expectedErr: ErrInvalidDuration},
+	}
+
+	for _, test := range tests {
+		length, err := parseLength(test.value)
+		if !errors.Is(err, test.expectedErr) {
+			t.Errorf("%s: expected error %v, but got %v", test.value, test.expectedErr, err)
+			continue
+		}
+		if length != test.expected {
+			t.Errorf("%s: expected %+v, but got %+v", test.value, test.expected, length)
+		}
+	}
+}
+
+func TestParseLengthTooLarge(t *testing.T) {
+	for _, value := range []string{"PT9999999999H", "PT2562047H50M", "P99999999999999999999D", "P2000000000000000000W"} {
+		_, err := parseLength(value)
+		if err == nil || !strings.Contains(err.Error(), "too large") {
+			t.Errorf("%s: expected a too large error, but got %v", value, err)
+		}
+	}
+
+	// The largest length that fits is still accepted
+	if _, err := parseLength("PT2562047H47M16S"); err != nil {
+		t.Errorf("Expected PT2562047H47M16S to be accepted, but got %v", err)
+	}
+}
@@ -0,0 +1,101 @@
+package slachecker
+
+import (
+	"fmt"
+	"time"
+)
+
+// PriorityMatrix maps a ticket's priority, and optionally its customer tier, to the SLA that applies to it
+type PriorityMatrix struct {
+	Priorities []string       // Every priority a ticket can have, e.g. "P1" to "P4"
+	Rules      []PriorityRule // Each priority needs a rule without a tier, which applies to any tier without a rule of its own
+}
+
+// PriorityRule gives the SLA for tickets of one priority, optionally only for one customer tier
+type PriorityRule struct {
+	Priority string // One of the matrix's priorities
+	Tier     string // The customer tier the rule applies to, or empty for every other tier
+	SLA      SLA    // Length and calendar for matching tickets; StartTime is set per ticket
+}
+
+// Validate checks every priority is named, unique and covered by a rule without a tier, and every rule
+// is for a known priority, unique and holds a valid SLA. Failures are reported as a *ValidationError, e.g. against
+// "Priorities[2]" or "Rules[1].SLA.TimeUnit".
+func (m *PriorityMatrix) Validate() error {
+	// Validate Priorities
+	if len(m.Priorities) == 0 {
+		return &ValidationError{Field: "Priorities", Err: fmt.Errorf("%w: no priorities defined", ErrUncoveredPriority)}
+	}
+	priorities := make(map[string]bool, len(m.Priorities))
+	for i, priority := range m.Priorities {
+		field := fmt.Sprintf("Priorities[%d]", i)
+		if priority == "" {
+			return &ValidationError{Field: field, Err: fmt.Errorf("%w: name cannot be empty", ErrInvalidPriority)}
+		}
+		if priorities[priority] {
+			return &ValidationError{Field: field, Err: fmt.Errorf("%w: duplicate priority %q", ErrInvalidPriority, priority)}
+		}
+		priorities[priority] = true
+	}
+
+	// Validate Rules
+	type ruleKey struct{ priority, tier string }
+	rules := make(map[ruleKey]bool, len(m.Rules))
+	for i, rule := range m.Rules {
+		field := fmt.Sprintf("Rules[%d]", i)
+		if !priorities[rule.Priority] {
+			return &ValidationError{Field: field + ".Priority", Err: fmt.Errorf("%w: unknown priority %q", ErrInvalidRule, rule.Priority)}
+		}
+
+		key := ruleKey{priority: rule.Priority, tier: rule.Tier}
+		if rules[key] {
+			return &ValidationError{Field: field, Err: fmt.Errorf("%w: duplicate rule for priority %q and tier %q", ErrInvalidRule, rule.Priority, rule.Tier)}
+		}
+		rules[key] = true
+
+		sla := rule.SLA
+		if err := sla.Validate(); err != nil {
+			return withField(field+".SLA", err)
+		}
+	}
+
+	// Every priority needs a rule for any tier
+	for i, priority := range m.Priorities {
+		if !rules[ruleKey{priority: priority}] {
+			return &ValidationError{Field: fmt.Sprintf("Priorities[%d]", i), Err: fmt.Errorf("%w: %q", ErrUncoveredPriority, priority)}
+		}
+	}
+	return nil
+}
+
+// NewSLA returns the SLA for a ticket of the given priority and customer tier starting at startTime.
+// A rule for the tier takes precedence over the priority's rule for every tier; pass an empty tier
+// when tickets have none.
+func (m PriorityMatrix) NewSLA(priority, tier string, startTime time.Time) (SLA, error) {
+	rule, ok := m.rule(priority, tier)
+	if !ok {
+		return SLA{}, fmt.Errorf("%w: %q", ErrUncoveredPriority, priority)
+	}
+
+	sla := rule.SLA
+	sla.StartTime = startTime
+	return sla, nil
+}
+
+// rule returns the rule for the priority and tier, falling back to the priority's rule for every tier
+func (m PriorityMatrix) rule(priority, tier string) (PriorityRule, bool) {
+	var fallback PriorityRule
+	found := false
+	for _, rule := range m.Rules {
+		if rule.Priority != priority {
+			continue
+		}
+		if rule.Tier == tier {
+			return rule, true
+		}
+		if rule.Tier == "" {
+			fallback, found = rule, true
+		}
+	}
+	return fallback, found
+}
@@ -0,0 +1,135 @@
+package slachecker
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func setupPriorityMatrix() PriorityMatrix {
+	businessHours := setupSLAWithHolidays(nil)
+	businessHours.SLALength = 2
+	businessHours.TimeUnit = TimeUnitBusinessDays
+
+	return PriorityMatrix{
+		Priorities: []string{"P1", "P3"},
+		Rules: []PriorityRule{
+			{Priority: "P1", SLA: SLA{SLALength: 4, TimeUnit: "hours", ClockMode: ClockModeCalendar}},
+			{Priority: "P1", Tier: "gold", SLA: SLA{Length: "PT2H", ClockMode: ClockModeCalendar}},
+			{Priority: "P3", SLA: businessHours},
+		},
+	}
+}
+
+func TestPriorityMatrixNewSLA(t *testing.T) {
+	startTime := time.Date(2024, time.August, 30, 16, 0, 0, 0, time.UTC) // Friday 4 PM
+
+	tests := []struct {
+		priority         string
+		tier             string
+		expectedDeadline time.Time
+	}{
+		{priority: "P1", expectedDeadline: time.Date(2024, time.August, 30, 20, 0, 0, 0, time.UTC)},
+		{priority: "P1", tier: "silver", expectedDeadline: time.Date(2024, time.August, 30, 20, 0, 0, 0, time.UTC)},
+		{priority: "P1", tier: "gold", expectedDeadline: time.Date(2024, time.August, 30, 18, 0, 0, 0, time.UTC)},
+		{priority: "P3", tier: "gold", expectedDeadline: time.Date(2024, time.September, 3, 16, 0, 0, 0, time.UTC)},
+	}
+
+	matrix := setupPriorityMatrix()
+	if err := matrix.Validate(); err != nil {
+		t.Fatalf("Unexpected validation error: %v", err)
+	}
+
+	for _, test := range tests {
+		sla, err := matrix.NewSLA(test.priority, test.tier, startTime)
+		if err != nil {
+			t.Fatalf("%s/%s: unexpected error: %v", test.priority, test.tier, err)
+		}
+		deadline, err := sla.Deadline(startTime)
+		if err != nil {
+			t.Fatalf("%s/%s: unexpected error: %v", test.priority, test.tier, err)
+		}
+		if !deadline.Equal(test.expectedDeadline) {
+			t.Errorf("%s/%s: expected deadline %v, but got %v", test.priority, test.tier, test.expectedDeadline, deadline)
+		}
+	}
+
+	if _, err := matrix.NewSLA("P2", "", startTime); !errors.Is(err, ErrUncoveredPriority) {
+		t.Errorf("Expected error %v for an unknown priority, but got %v", ErrUncoveredPriority, err)
+	}
+}
+
+func TestValidatePriorityMatrix(t *testing.T) {
+	tests := []struct {
+		name          string
+		modify        func(matrix *PriorityMatrix)
+		expectedErr   error
+		expectedField string
+	}{
+		{
+			name:          "no priorities",
+			modify:        func(matrix *PriorityMatrix) { matrix.Priorities = nil },
+			expectedErr:   ErrUncoveredPriority,
+			expectedField: "Priorities",
+		},
+		{
+			name:          "uncovered priority",
+			modify:        func(matrix *PriorityMatrix) { matrix.Priorities = append(matrix.Priorities, "P2") },
+			expectedErr:   ErrUncoveredPriority,
+			expectedField: "Priorities[2]",
+		},
+		{
+			name:          "empty priority",
+			modify:        func(matrix *PriorityMatrix) { matrix.Priorities = append(matrix.Priorities, "") },
+			expectedErr:   ErrInvalidPriority,
+			expectedField: "Priorities[2]",
+		},
+		{
+			name:          "duplicate priority",
+			modify:        func(matrix *PriorityMatrix) { matrix.Priorities = append(matrix.Priorities, "P1") },
+			expectedErr:   ErrInvalidPriority,
+			expectedField: "Priorities[2]",
+		},
+		{
+			name:          "only covered for one tier",
+			modify:        func(matrix *PriorityMatrix) { matrix.Rules = matrix.Rules[1:] },
+			expectedErr:   ErrUncoveredPriority,
+			expectedField: "Priorities[0]",
+		},
+		{
+			name:          "unknown priority",
+			modify:        func(matrix *PriorityMatrix) { matrix.Rules[2].Priority = "P4" },
+			expectedErr:   ErrInvalidRule,
+			expectedField: "Rules[2].Priority",
+		},
+		{
+			name:          "duplicate rule",
+			modify:        func(matrix *PriorityMatrix) { matrix.Rules[1].Tier = "" },
+			expectedErr:   ErrInvalidRule,
+			expectedField: "Rules[1]",
+		},
+		{
+			name:          "invalid SLA",
+			modify:        func(matrix *PriorityMatrix) { matrix.Rules[2].SLA.TimeUnit = "fortnights" },
+			expectedErr:   ErrInvalidTimeUnit,
+			expectedField: "Rules[2].SLA.TimeUnit",
+		},
+	}
+
+	for _, test := range tests {
+		matrix := setupPriorityMatrix()
+		test.modify(&matrix)
+
+		err := matrix.Validate()
+		if !errors.Is(err, test.expectedErr) {
+			t.Errorf("%s: expected error %v, but got %v", test.name, test.expectedErr, err)
+		}
+
+		var validationErr *ValidationError
+		if !errors.As(err, &validationErr) {
+			t.Errorf("%s: expected a *ValidationError, but got %T", test.name, err)
+		} else if validationErr.Field != test.expectedField {
+			t.Errorf("%s: expected field %q, but got %q", test.name, test.expectedField, validationErr.Field)
+		}
+	}
+}
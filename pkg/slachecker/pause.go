@@ -0,0 +1,107 @@
+package slachecker
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Pause is a period during which the SLA clock is stopped, e.g. while a ticket is waiting on the customer
+type Pause struct {
+	Start time.Time
+	End   time.Time // Zero while the pause is still open
+}
+
+// validate checks the pause starts, and ends after it starts when it is closed
+func (p Pause) validate() error {
+	if p.Start.IsZero() {
+		return fmt.Errorf("%w: start cannot be empty", ErrInvalidPause)
+	}
+	if !p.End.IsZero() && !p.End.After(p.Start) {
+		return fmt.Errorf("%w: end must be after start", ErrInvalidPause)
+	}
+	return nil
+}
+
+// isPaused reports whether a pause has stopped the clock at t
+func (s SLA) isPaused(t time.Time) bool {
+	for _, pause := range s.Pauses {
+		if !t.Before(pause.Start) && (pause.End.IsZero() || t.Before(pause.End)) {
+			return true
+		}
+	}
+	return false
+}
+
+// resolvePauses returns the SLA's pauses as intervals ordered by start.
+// Pauses that are still open are treated as running until currentTime.
+func (s SLA) resolvePauses(currentTime time.Time) []interval {
+	pauses := make([]interval, 0, len(s.Pauses))
+	for _, pause := range s.Pauses {
+		end := pause.End
+		if end.IsZero() {
+			end = currentTime
+		}
+		if pause.Start.Before(end) {
+			pauses = append(pauses, interval{start: pause.Start, end: end})
+		}
+	}
+
+	sort.Slice(pauses, func(i, j int) bool {
+		return pauses[i].start.Before(pauses[j].start)
+	})
+	return pauses
+}
+
+// nextClockInterval returns the interval containing t, or the next one after t, during which the SLA
+// clock is running: business time that is not covered by any of the pauses.
+func (c *Calendar) nextClockInterval(t time.Time, pauses []interval) (time.Time, time.Time) {
+	for {
+		intervalStart, intervalEnd := c.nextBusinessInterval(t)
+
+		paused := false
+		for _, pause := range pauses {
+			if !intervalStart.Before(pause.start) && intervalStart.Before(pause.end) {
+				// The interval opens during a pause, resume looking once the pause ends
+				t = pause.end
+				paused = true
+				break
+			}
+			if pause.start.After(intervalStart) && pause.start.Before(intervalEnd) {
+				// A pause starts part way through the interval and stops the clock
+				intervalEnd = pause.start
+			}
+		}
+
+		if !paused {
+			return intervalStart, intervalEnd
+		}
+	}
+}
+
+// runningTimeBetween returns the business time between from and to during which the clock is not paused
+func (c *Calendar) runningTimeBetween(from, to time.Time, pauses []interval) time.Duration {
+	if !c.singleUse {
+		return c.indexedRunningTime(from, to, pauses)
+	}
+
+	total := time.Duration(0)
+	currentTime := from
+
+	for currentTime.Before(to) {
+		intervalStart, intervalEnd := c.nextClockInterval(currentTime, pauses)
+		if !intervalStart.Before(to) {
+			break
+		}
+
+		// If the interval runs past the end, adjust it
+		if intervalEnd.After(to) {
+			intervalEnd = to
+		}
+
+		total += intervalEnd.Sub(intervalStart)
+		currentTime = intervalEnd
+	}
+
+	return total
+}
@@ -0,0 +1,54 @@
+package slachecker
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPauseValidate(t *testing.T) {
+	start := time.Date(2024, time.August, 27, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		pause       Pause
+		expectError bool
+	}{
+		{name: "closed pause", pause: Pause{Start: start, End: start.Add(time.Hour)}},
+		{name: "open pause", pause: Pause{Start: start}},
+		{name: "missing start", pause: Pause{End: start}, expectError: true},
+		{name: "end before start", pause: Pause{Start: start, End: start.Add(-time.Minute)}, expectError: true},
+		{name: "empty pause", pause: Pause{Start: start, End: start}, expectError: true},
+	}
+
+	for _, test := range tests {
+		err := test.pause.validate()
+		if test.expectError && err == nil {
+			t.Errorf("%s: expected a validation error, but got none", test.name)
+		}
+		if !test.expectError && err != nil {
+			t.Errorf("%s: unexpected validation error: %v", test.name, err)
+		}
+	}
+}
+
+func TestResolvePauses(t *testing.T) {
+	currentTime := time.Date(2024, time.August, 27, 12, 0, 0, 0, time.UTC)
+
+	sla := setupSLAWithHolidays(nil)
+	sla.Pauses = []Pause{
+		{Start: time.Date(2024, time.August, 27, 11, 0, 0, 0, time.UTC)},                                                              // Still open
+		{Start: time.Date(2024, time.August, 27, 9, 0, 0, 0, time.UTC), End: time.Date(2024, time.August, 27, 10, 0, 0, 0, time.UTC)}, // Closed
+		{Start: time.Date(2024, time.August, 27, 13, 0, 0, 0, time.UTC)},                                                              // Opens after currentTime
+	}
+
+	pauses := sla.resolvePauses(currentTime)
+	if len(pauses) != 2 {
+		t.Fatalf("Expected 2 pauses, but got %d", len(pauses))
+	}
+	if !pauses[0].start.Equal(sla.Pauses[1].Start) || !pauses[0].end.Equal(sla.Pauses[1].End) {
+		t.Errorf("Expected the closed pause first, but got %v-%v", pauses[0].start, pauses[0].end)
+	}
+	if !pauses[1].start.Equal(sla.Pauses[0].Start) || !pauses[1].end.Equal(currentTime) {
+		t.Errorf("Expected the open pause to end at %v, but got %v-%v", currentTime, pauses[1].start, pauses[1].end)
+	}
+}
@@ -0,0 +1,125 @@
+package slachecker
+
+import (
+	"fmt"
+	"time"
+)
+
+// Policy is a set of named targets tracked independently against one calendar and start time,
+// e.g. first response within 1 business hour and resolution within 3 business days
+type Policy struct {
+	SLA     SLA      // Start time, calendar and pauses shared by every target; its length, CompletedAt and AtRisk are ignored
+	Targets []Target // The targets to track, each with a unique name
+}
+
+// Target is one clock of a Policy, with its own length, completion time and at-risk threshold
+type Target struct {
+	Name        string          // e.g. "firstResponse" or "resolution"
+	SLALength   int             // Target duration, e.g., 4
+	TimeUnit    string          // Target time unit, one of the TimeUnit constants
+	Length      string          // ISO 8601 or Go duration; overrides SLALength and TimeUnit when set
+	StartTime   time.Time       // Overrides the policy's start time when set, e.g. the customer's latest reply for a next response target
+	CompletedAt time.Time       // When the target was completed, stopping its clock; zero while it is still running
+	AtRisk      AtRiskThreshold // When the running target is reported as at risk
+}
+
+// PolicyResult lists the result of every target of a Policy
+type PolicyResult struct {
+	IsWithinSLA bool           `json:"isWithinSLA"` // No target has been breached
+	Status      Status         `json:"status"`      // The most severe status of any target
+	Targets     []TargetResult `json:"targets"`     // In the order the targets are defined
+}
+
+// TargetResult is the result of one target of a Policy
+type TargetResult struct {
+	Name   string    `json:"name"`
+	Result SLAResult `json:"result"`
+}
+
+// Validate checks the shared calendar and pauses, then every target.
+// Failures are reported as a *ValidationError, e.g. against "SLA.BusinessHours" or "Targets[1].Length".
+func (p *Policy) Validate() error {
+	// Validate the shared business calendar and pauses
+	if err := p.SLA.validateCalendar(p.SLA.ownCalendar()); err != nil {
+		return withField("SLA", err)
+	}
+	if err := p.SLA.validatePauses(); err != nil {
+		return withField("SLA", err)
+	}
+
+	// Validate Targets
+	if len(p.Targets) == 0 {
+		return &ValidationError{Field: "Targets", Err: ErrNoTargets}
+	}
+	names := make(map[string]bool, len(p.Targets))
+	for i, target := range p.Targets {
+		field := fmt.Sprintf("Targets[%d]", i)
+		if target.Name == "" {
+			return &ValidationError{Field: field + ".Name", Err: fmt.Errorf("%w: name cannot be empty", ErrInvalidTarget)}
+		}
+		if names[target.Name] {
+			return &ValidationError{Field: field + ".Name", Err: fmt.Errorf("%w: duplicate name %q", ErrInvalidTarget, target.Name)}
+		}
+		names[target.Name] = true
+
+		sla := p.targetSLA(target)
+		if err := sla.validateLength(); err != nil {
+			return withField(field, err)
+		}
+		if err := sla.validateTarget(); err != nil {
+			return withField(field, err)
+		}
+	}
+	return nil
+}
+
+// Evaluate validates the policy and evaluates each of its targets at currentTime
+func (p Policy) Evaluate(currentTime time.Time) (PolicyResult, error) {
+	if err := p.Validate(); err != nil {
+		return PolicyResult{}, err
+	}
+
+	result := PolicyResult{IsWithinSLA: true, Targets: make([]TargetResult, 0, len(p.Targets))}
+	for i, target := range p.Targets {
+		targetResult, err := p.targetSLA(target).Evaluate(currentTime)
+		if err != nil {
+			return PolicyResult{}, withField(fmt.Sprintf("Targets[%d]", i), err)
+		}
+
+		result.Targets = append(result.Targets, TargetResult{Name: target.Name, Result: targetResult})
+		result.IsWithinSLA = result.IsWithinSLA && targetResult.IsWithinSLA
+		if i == 0 || targetResult.Status.severity() > result.Status.severity() {
+			result.Status = targetResult.Status
+		}
+	}
+	return result, nil
+}
+
+// EvaluateNow evaluates the policy at the current time of its SLA's Clock
+func (p Policy) EvaluateNow() (PolicyResult, error) {
+	return p.Evaluate(now(p.SLA.Clock))
+}
+
+// Target returns the SLA tracking the named target, and false when the policy has no such target
+func (p Policy) Target(name string) (SLA, bool) {
+	for _, target := range p.Targets {
+		if target.Name == name {
+			return p.targetSLA(target), true
+		}
+	}
+	return SLA{}, false
+}
+
+// targetSLA returns the policy's SLA with the target's length, start, completion time and threshold
+func (p Policy) targetSLA(target Target) SLA {
+	sla := p.SLA
+	sla.SLALength = target.SLALength
+	sla.TimeUnit = target.TimeUnit
+	sla.Length = target.Length
+	if !target.StartTime.IsZero() {
+		sla.StartTime = target.StartTime
+	}
+	sla.CompletedAt = target.CompletedAt
+	sla.AtRisk = target.AtRisk
+	return sla
+}
@@ -0,0 +1,158 @@
+package slachecker
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func setupPolicy() Policy {
+	sla := setupSLAWithHolidays(nil)
+	sla.StartTime = time.Date(2024, time.August, 27, 9, 0, 0, 0, time.UTC) // Tuesday 9 AM
+
+	return Policy{
+		SLA: sla,
+		Targets: []Target{
+			{Name: "firstResponse", Length: "PT1H", CompletedAt: time.Date(2024, time.August, 27, 9, 30, 0, 0, time.UTC)},
+			{
+				Name:      "nextResponse",
+				SLALength: 2,
+				TimeUnit:  "hours",
+				StartTime: time.Date(2024, time.August, 27, 13, 0, 0, 0, time.UTC),
+				AtRisk:    AtRiskThreshold{PercentConsumed: 50},
+			},
+			{Name: "resolution", SLALength: 3, TimeUnit: TimeUnitBusinessDays},
+		},
+	}
+}
+
+func TestEvaluatePolicy(t *testing.T) {
+	tests := []struct {
+		name              string
+		currentTime       time.Time
+		expectedStatuses  []Status
+		expectedDeadlines []time.Time
+		expectedStatus    Status
+		expectedWithin    bool
+	}{
+		{
+			name:             "next response at risk",
+			currentTime:      time.Date(2024, time.August, 27, 14, 30, 0, 0, time.UTC),
+			expectedStatuses: []Status{StatusMet, StatusAtRisk, StatusOnTrack},
+			expectedDeadlines: []time.Time{
+				time.Date(2024, time.August, 27, 10, 0, 0, 0, time.UTC),
+				time.Date(2024, time.August, 27, 15, 0, 0, 0, time.UTC),
+				time.Date(2024, time.August, 30, 9, 0, 0, 0, time.UTC),
+			},
+			expectedStatus: StatusAtRisk,
+			expectedWithin: true,
+		},
+		{
+			name:             "breached",
+			currentTime:      time.Date(2024, time.August, 30, 10, 0, 0, 0, time.UTC),
+			expectedStatuses: []Status{StatusMet, StatusBreached, StatusBreached},
+			expectedDeadlines: []time.Time{
+				time.Date(2024, time.August, 27, 10, 0, 0, 0, time.UTC),
+				time.Date(2024, time.August, 27, 15, 0, 0, 0, time.UTC),
+				time.Date(2024, time.August, 30, 9, 0, 0, 0, time.UTC),
+			},
+			expectedStatus: StatusBreached,
+			expectedWithin: false,
+		},
+	}
+
+	for _, test := range tests {
+		result, err := setupPolicy().Evaluate(test.currentTime)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", test.name, err)
+		}
+
+		if len(result.Targets) != len(test.expectedStatuses) {
+			t.Fatalf("%s: expected %d target results, but got %d", test.name, len(test.expectedStatuses), len(result.Targets))
+		}
+		for i, target := range result.Targets {
+			if target.Result.Status != test.expectedStatuses[i] {
+				t.Errorf("%s: expected %s status %q, but got %q", test.name, target.Name, test.expectedStatuses[i], target.Result.Status)
+			}
+			if !target.Result.Deadline.Equal(test.expectedDeadlines[i]) {
+				t.Errorf("%s: expected %s deadline %v, but got %v", test.name, target.Name, test.expectedDeadlines[i], target.Result.Deadline)
+			}
+		}
+		if result.Status != test.expectedStatus {
+			t.Errorf("%s: expected status %q, but got %q", test.name, test.expectedStatus, result.Status)
+		}
+		if result.IsWithinSLA != test.expectedWithin {
+			t.Errorf("%s: expected IsWithinSLA to be %v, but got %v", test.name, test.expectedWithin, result.IsWithinSLA)
+		}
+	}
+}
+
+func TestValidatePolicy(t *testing.T) {
+	tests := []struct {
+		name          string
+		modify        func(policy *Policy)
+		expectedErr   error
+		expectedField string
+	}{
+		{name: "valid", modify: func(policy *Policy) {}},
+		{name: "shared calendar", modify: func(policy *Policy) { policy.SLA.ValidDays = nil }, expectedErr: ErrNoValidDays, expectedField: "SLA.ValidDays"},
+		{name: "no targets", modify: func(policy *Policy) { policy.Targets = nil }, expectedErr: ErrNoTargets, expectedField: "Targets"},
+		{name: "empty name", modify: func(policy *Policy) { policy.Targets[0].Name = "" }, expectedErr: ErrInvalidTarget, expectedField: "Targets[0].Name"},
+		{
+			name:          "duplicate name",
+			modify:        func(policy *Policy) { policy.Targets[2].Name = "firstResponse" },
+			expectedErr:   ErrInvalidTarget,
+			expectedField: "Targets[2].Name",
+		},
+		{name: "target length", modify: func(policy *Policy) { policy.Targets[1].Length = "P1Y" }, expectedErr: ErrInvalidDuration, expectedField: "Targets[1].Length"},
+		{
+			name: "completed before the target started",
+			modify: func(policy *Policy) {
+				policy.Targets[1].CompletedAt = time.Date(2024, time.August, 27, 12, 0, 0, 0, time.UTC)
+			},
+			expectedErr:   ErrInvalidCompletion,
+			expectedField: "Targets[1].CompletedAt",
+		},
+	}
+
+	for _, test := range tests {
+		policy := setupPolicy()
+		test.modify(&policy)
+
+		err := policy.Validate()
+		if !errors.Is(err, test.expectedErr) {
+			t.Errorf("%s: expected error %v, but got %v", test.name, test.expectedErr, err)
+		}
+		if test.expectedErr == nil {
+			continue
+		}
+
+		var validationErr *ValidationError
+		if !errors.As(err, &validationErr) {
+			t.Errorf("%s: expected a *ValidationError, but got %T", test.name, err)
+		} else if validationErr.Field != test.expectedField {
+			t.Errorf("%s: expected field %q, but got %q", test.name, test.expectedField, validationErr.Field)
+		}
+	}
+}
+
+func TestPolicyTarget(t *testing.T) {
+	policy := setupPolicy()
+
+	sla, ok := policy.Target("resolution")
+	if !ok {
+		t.Fatalf("Expected to find the resolution target")
+	}
+	deadline, err := sla.Deadline(sla.StartTime)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := time.Date(2024, time.August, 30, 9, 0, 0, 0, time.UTC)
+	if !deadline.Equal(expected) {
+		t.Errorf("Expected deadline to be %v, but got %v", expected, deadline)
+	}
+
+	if _, ok := policy.Target("escalation"); ok {
+		t.Errorf("Expected no escalation target")
+	}
+}
@@ -0,0 +1,101 @@
+package slachecker
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// WeeklySchedule maps each day of the week to its business windows.
+// Days that are missing from the schedule, or have no windows, are closed.
+type WeeklySchedule map[time.Weekday][]BusinessHours
+
+// DayHours gives the business windows on one calendar date, e.g. 09:00-13:00 on Christmas Eve
+type DayHours struct {
+	Date  time.Time       // Matched on its calendar date, like Holidays
+	Hours []BusinessHours // Business windows on that date
+}
+
+// validate checks the date is set and its windows are valid and do not overlap
+func (d DayHours) validate() error {
+	if d.Date.IsZero() {
+		return &ValidationError{Field: "Date", Err: ErrInvalidHoliday}
+	}
+	if len(d.Hours) == 0 {
+		return &ValidationError{Field: "Hours", Err: fmt.Errorf("%w: no business windows", ErrInvalidBusinessHours)}
+	}
+	if err := validateWindows(sortWindows(d.Hours)); err != nil {
+		return withField("Hours", err)
+	}
+	return nil
+}
+
+// windowsOn returns the business windows for the given weekday ordered by opening time
+func (w WeeklySchedule) windowsOn(day time.Weekday) []BusinessHours {
+	return sortWindows(w[day])
+}
+
+// validate checks every day has valid, non-overlapping windows and that at least one day is open
+func (w WeeklySchedule) validate() error {
+	open := false
+	for day, windows := range w {
+		if day < time.Sunday || day > time.Saturday {
+			return &ValidationError{Field: fmt.Sprintf("[%d]", day), Err: ErrInvalidDay}
+		}
+		if len(windows) > 0 {
+			open = true
+		}
+	}
+	if !open {
+		return fmt.Errorf("%w: schedule must have business windows on at least one day", ErrNoValidDays)
+	}
+
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		if err := validateWindows(w.windowsOn(day)); err != nil {
+			return withField(fmt.Sprintf("[%v]", day), err)
+		}
+	}
+	return w.validateOvernight()
+}
+
+// validateOvernight checks that no overnight window runs into the first window of the following day
+func (w WeeklySchedule) validateOvernight() error {
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		windows := w.windowsOn(day)
+		if len(windows) == 0 || !windows[len(windows)-1].overnight() {
+			continue
+		}
+
+		nextDay := (day + 1) % 7
+		nextWindows := w.windowsOn(nextDay)
+		overnight := windows[len(windows)-1]
+		if len(nextWindows) > 0 && nextWindows[0].Start.Before(overnight.End) {
+			return fmt.Errorf("%w: %v on %v and %v on %v", ErrOverlappingWindows, overnight, day, nextWindows[0], nextDay)
+		}
+	}
+	return nil
+}
+
+// sortWindows returns a copy of the windows ordered by opening time
+func sortWindows(windows []BusinessHours) []BusinessHours {
+	sorted := make([]BusinessHours, len(windows))
+	copy(sorted, windows)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Start.Before(sorted[j].Start)
+	})
+	return sorted
+}
+
+// validateWindows checks each window is valid and that none of them overlap on the day they open.
+// The windows must already be ordered by opening time.
+func validateWindows(windows []BusinessHours) error {
+	for i, window := range windows {
+		if err := window.validate(); err != nil {
+			return err
+		}
+		if i > 0 && window.Start.minutes() < windows[i-1].closingMinutes() {
+			return fmt.Errorf("%w: %v and %v", ErrOverlappingWindows, windows[i-1], window)
+		}
+	}
+	return nil
+}
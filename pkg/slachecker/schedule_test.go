@@ -0,0 +1,87 @@
+package slachecker
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWeeklyScheduleValidate(t *testing.T) {
+	morning := BusinessHours{Start: NewTimeOfDay(9, 0), End: NewTimeOfDay(13, 0)}
+	afternoon := BusinessHours{Start: NewTimeOfDay(14, 0), End: NewTimeOfDay(18, 0)}
+	day := BusinessHours{Start: NewTimeOfDay(8, 0), End: NewTimeOfDay(18, 0)}
+
+	tests := []struct {
+		name        string
+		schedule    WeeklySchedule
+		expectError bool
+	}{
+		{
+			name:     "distinct days",
+			schedule: WeeklySchedule{time.Friday: {day}, time.Saturday: {morning}},
+		},
+		{
+			name:     "split day",
+			schedule: WeeklySchedule{time.Monday: {afternoon, morning}, time.Sunday: nil},
+		},
+		{
+			name:        "no open days",
+			schedule:    WeeklySchedule{time.Sunday: nil},
+			expectError: true,
+		},
+		{
+			name:        "overlapping windows",
+			schedule:    WeeklySchedule{time.Monday: {morning, day}},
+			expectError: true,
+		},
+		{
+			name:        "invalid window",
+			schedule:    WeeklySchedule{time.Monday: {{Start: NewTimeOfDay(18, 0), End: NewTimeOfDay(18, 0)}}},
+			expectError: true,
+		},
+		{
+			name:     "overnight window",
+			schedule: WeeklySchedule{time.Monday: {{Start: NewTimeOfDay(22, 0), End: NewTimeOfDay(6, 0)}}, time.Tuesday: {day}},
+		},
+		{
+			name:        "overnight window overlaps next day",
+			schedule:    WeeklySchedule{time.Monday: {{Start: NewTimeOfDay(22, 0), End: NewTimeOfDay(9, 0)}}, time.Tuesday: {day}},
+			expectError: true,
+		},
+		{
+			name:        "overnight window overlaps next week",
+			schedule:    WeeklySchedule{time.Saturday: {{Start: NewTimeOfDay(22, 0), End: NewTimeOfDay(9, 0)}}, time.Sunday: {day}},
+			expectError: true,
+		},
+		{
+			name:        "window after overnight window",
+			schedule:    WeeklySchedule{time.Monday: {{Start: NewTimeOfDay(22, 0), End: NewTimeOfDay(6, 0)}, {Start: NewTimeOfDay(23, 0), End: NewTimeOfDay(23, 30)}}},
+			expectError: true,
+		},
+		{
+			name:        "invalid day",
+			schedule:    WeeklySchedule{time.Weekday(7): {day}},
+			expectError: true,
+		},
+	}
+
+	for _, test := range tests {
+		err := test.schedule.validate()
+		if test.expectError && err == nil {
+			t.Errorf("%s: expected a validation error, but got none", test.name)
+		}
+		if !test.expectError && err != nil {
+			t.Errorf("%s: unexpected validation error: %v", test.name, err)
+		}
+	}
+}
+
+func TestValidateWithScheduleIgnoresValidDays(t *testing.T) {
+	// The schedule replaces ValidDays, so an empty ValidDays is fine
+	sla := setupSLAWithHolidays(nil)
+	sla.ValidDays = nil
+	sla.Schedule = WeeklySchedule{time.Saturday: {{Start: NewTimeOfDay(9, 0), End: NewTimeOfDay(13, 0)}}}
+
+	if err := sla.Validate(); err != nil {
+		t.Errorf("Unexpected validation error: %v", err)
+	}
+}
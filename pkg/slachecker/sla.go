@@ -1,128 +1,197 @@
 package slachecker
 
 import (
-	"errors"
+	"encoding/json"
 	"fmt"
 	"time"
 )
 
+// Time units accepted in SLA.TimeUnit
+const (
+	TimeUnitSeconds      = "seconds"
+	TimeUnitMinutes      = "minutes"
+	TimeUnitHours        = "hours"
+	TimeUnitDays         = "days"         // 24 hours of business time per day
+	TimeUnitBusinessDays = "businessDays" // The same time of day N business days later
+)
+
 type SLA struct {
-	StartTime     time.Time
-	SLALength     int    // SLA duration, e.g., 4
-	TimeUnit      string // SLA time unit, e.g., "hours", "minutes"
-	BusinessHours struct {
-		StartHour int
-		EndHour   int
-	}
-	ValidDays      []time.Weekday // e.g., []time.Weekday{time.Monday, time.Tuesday, ...}
-	Holidays       []time.Time    // Specific holidays when SLA is not applicable
-	IgnoreHolidays bool           // Should holidays be taking into account when calculating SLAs
+	StartTime       time.Time
+	SLALength       int             // SLA duration, e.g., 4
+	TimeUnit        string          // SLA time unit, one of the TimeUnit constants, e.g., "hours", "businessDays"
+	Length          string          // ISO 8601 or Go duration, e.g. "PT1H30M", "P2D" or "1h30m"; overrides SLALength and TimeUnit when set
+	BusinessHours   BusinessHours   // Daily opening and closing times, e.g. 08:30-17:45
+	BusinessWindows []BusinessHours // Several non-overlapping windows per day, e.g. 09:00-12:00 and 13:00-17:30; overrides BusinessHours when set
+	Schedule        WeeklySchedule  // Business windows per weekday; when set, ValidDays, BusinessHours and BusinessWindows are ignored
+	ValidDays       []time.Weekday  // e.g., []time.Weekday{time.Monday, time.Tuesday, ...}
+	Holidays        []time.Time     // Specific holidays when SLA is not applicable
+	ReducedHours    []DayHours      // Business windows replacing the usual ones on specific dates, e.g. half days, even on holidays; ignored along with Holidays
+	ExtraHours      []DayHours      // Extra business windows on specific dates, e.g. a make-up Saturday; open even on holidays
+	Closures        []Closure       // Periods when the business is closed whatever the schedule says, e.g. an office move
+	IgnoreHolidays  bool            // Should holidays be taking into account when calculating SLAs
+	Location        *time.Location  // Time zone business hours, valid days and holidays are interpreted in, defaults to UTC
+	CountryCode     string          // ISO 3166-1 alpha-2 code of the country the holidays are for, e.g. "GB"; informational only
+	Calendar        *Calendar       // Shared business calendar; when set, the fields above from BusinessHours to CountryCode are ignored
+	Pauses          []Pause         // Periods when the clock is stopped; the deadline is extended by the business time spent paused
+	ClockMode       ClockMode       // Business hours or around the clock, defaults to ClockModeBusiness
+	CompletedAt     time.Time       // When the work was completed, stopping the clock; zero while it is still running
+	AtRisk          AtRiskThreshold // When a running SLA is reported as at risk
+	Clock           Clock           // Tells the current time to EvaluateNow and CheckNow, defaults to the system clock
 }
 
-// SLAResult contains the details about SLA status
+// SLAResult contains the details about SLA status.
+// Each duration is available both as a human-readable "HH:MM:SS" string and as a time.Duration;
+// the JSON encoding carries the strings alongside the durations in seconds.
 type SLAResult struct {
 	IsWithinSLA          bool      `json:"isWithinSLA"`
+	Status               Status    `json:"status"`
 	Deadline             time.Time `json:"deadline"`
 	Remaining            string    `json:"remaining"`
 	Overage              string    `json:"overage,omitempty"`
 	WorkingTimeRemaining string    `json:"workingTimeRemaining"`
+	Elapsed              string    `json:"elapsed"`
+	PausedTime           string    `json:"pausedTime"`
+	PercentConsumed      float64   `json:"percentConsumed"` // Business time elapsed as a percentage of the SLA, above 100 once breached
+
+	RemainingDuration            time.Duration `json:"-"` // Calendar time until the deadline
+	OverageDuration              time.Duration `json:"-"` // Calendar time since the deadline passed
+	WorkingTimeRemainingDuration time.Duration `json:"-"` // Business time until the deadline
+	ElapsedDuration              time.Duration `json:"-"` // Business time since the start, excluding pauses
+	PausedDuration               time.Duration `json:"-"` // Business time spent paused so far
 }
 
-// Validate checks if the SLA configuration is valid
+// Validate checks if the SLA configuration is valid.
+// Failures are reported as a *ValidationError wrapping one of the Err sentinel errors.
 func (s *SLA) Validate() error {
+	return s.validate(s.ownCalendar())
+}
+
+// validate checks the SLA like Validate, against its own calendar built by the caller, so that an
+// evaluation builds a calendar from the SLA's own fields only once
+func (s *SLA) validate(own *Calendar) error {
+	// Validate the length
+	if err := s.validateLength(); err != nil {
+		return err
+	}
+
+	// Validate the business calendar
+	if err := s.validateCalendar(own); err != nil {
+		return err
+	}
+
+	// Validate Pauses
+	if err := s.validatePauses(); err != nil {
+		return err
+	}
+
+	// Validate the completion time and at-risk threshold
+	if err := s.validateTarget(); err != nil {
+		return err
+	}
+
+	// Return nil if all validations pass
+	return nil
+}
+
+// validateLength checks Length, or SLALength and TimeUnit when Length is not set
+func (s *SLA) validateLength() error {
+	if s.Length != "" {
+		// Validate Length, which replaces SLALength and TimeUnit
+		_, err := s.length()
+		return err
+	}
+
 	// Validate SLALength
 	if s.SLALength <= 0 {
-		return errors.New("SLA length must be greater than zero")
+		return &ValidationError{Field: "SLALength", Err: ErrInvalidLength}
 	}
 
 	// Validate TimeUnit
 	validTimeUnits := map[string]bool{
-		"seconds": true, "minutes": true, "hours": true, "days": true,
+		TimeUnitSeconds: true, TimeUnitMinutes: true, TimeUnitHours: true, TimeUnitDays: true,
+		TimeUnitBusinessDays: true,
 	}
 	if !validTimeUnits[s.TimeUnit] {
-		return errors.New("invalid time unit: " + s.TimeUnit)
-	}
-
-	// Validate BusinessHours
-	if s.BusinessHours.StartHour < 0 || s.BusinessHours.StartHour >= 24 {
-		return errors.New("business start hour must be between 0 and 23")
-	}
-	if s.BusinessHours.EndHour <= s.BusinessHours.StartHour || s.BusinessHours.EndHour > 24 {
-		return errors.New("business end hour must be greater than start hour and less than or equal to 24")
+		return &ValidationError{Field: "TimeUnit", Err: fmt.Errorf("%w: %q", ErrInvalidTimeUnit, s.TimeUnit)}
 	}
+	return nil
+}
 
-	// Validate ValidDays
-	if len(s.ValidDays) == 0 {
-		return errors.New("valid days cannot be empty")
-	}
-	for _, day := range s.ValidDays {
-		if day < time.Sunday || day > time.Saturday {
-			return errors.New("invalid day in valid days")
+// validatePauses checks each of the pauses
+func (s *SLA) validatePauses() error {
+	for i, pause := range s.Pauses {
+		if err := pause.validate(); err != nil {
+			return withField(fmt.Sprintf("Pauses[%d]", i), err)
 		}
 	}
+	return nil
+}
 
-	// Validate Holidays (optional, as holidays are valid dates)
-	for _, holiday := range s.Holidays {
-		if holiday.IsZero() {
-			return errors.New("invalid holiday date")
-		}
+// validateTarget checks the completion time and at-risk threshold
+func (s *SLA) validateTarget() error {
+	// Validate CompletedAt (optional, as the work may still be in progress)
+	if !s.CompletedAt.IsZero() && s.CompletedAt.Before(s.StartTime) {
+		return &ValidationError{Field: "CompletedAt", Err: ErrInvalidCompletion}
 	}
 
-	// Return nil if all validations pass
+	// Validate the at-risk threshold
+	if err := s.AtRisk.validate(); err != nil {
+		return withField("AtRisk", err)
+	}
 	return nil
 }
 
-// IsWithinSLA checks if the current time is within the SLA duration from the start time.
-func (s SLA) IsWithinSLA(currentTime time.Time) bool {
-	// Validate SLA configuration
-	if err := s.Validate(); err != nil {
-		fmt.Println("Validation error:", err)
-		return false
+// validateCalendar checks the clock mode and the SLA's own calendar, from ownCalendar, the SLA clock runs on
+func (s *SLA) validateCalendar(own *Calendar) error {
+	if !s.ClockMode.isValid() {
+		return &ValidationError{Field: "ClockMode", Err: fmt.Errorf("%w: %q", ErrInvalidClockMode, s.ClockMode)}
 	}
 
-	// Calculate the SLA deadline based on business hours, weekends, and holidays
-	slaDeadline, err := s.calculateSLADeadline()
-	if err != nil {
-		// Handle the error (log it, return a special SLA result, etc.)
-		fmt.Println("Error calculating SLA deadline:", err)
-		return false
+	// A shared calendar is validated once, on its first use. In the calendar clock modes the clock
+	// runs around the clock, so business hours and valid days are not used.
+	hoursErr, err := own.validated()
+	if hoursErr != nil && !s.ClockMode.isCalendar() {
+		err = hoursErr
+	}
+	if err != nil && s.Calendar != nil {
+		return withField("Calendar", err)
 	}
+	return err
+}
 
-	// Check if the current time is before the calculated SLA deadline
-	return currentTime.Before(slaDeadline)
+// Deadline validates the SLA and calculates its deadline as of currentTime.
+// Open pauses keep extending the deadline, which is why it depends on currentTime.
+func (s SLA) Deadline(currentTime time.Time) (time.Time, error) {
+	own := s.ownCalendar()
+	if err := s.validate(own); err != nil {
+		return time.Time{}, err
+	}
+	return s.calculateSLADeadline(s.clockCalendar(own), currentTime)
 }
 
-// CheckSLA checks if the current time is within the SLA duration and returns additional details
-func (s SLA) CheckSLA(currentTime time.Time) SLAResult {
-	// Validate SLA configuration
-	if err := s.Validate(); err != nil {
-		fmt.Println("Validation error:", err)
-		return SLAResult{
-			IsWithinSLA: false,
-			Deadline:    time.Time{},
-			Remaining:   "N/A",
-			Overage:     "N/A",
-		}
+// Evaluate validates the SLA and checks it at currentTime, returning the details of its status.
+// Once the work is completed the clock stops, and the SLA is evaluated as of CompletedAt instead.
+func (s SLA) Evaluate(currentTime time.Time) (SLAResult, error) {
+	completed := s.isCompleted(currentTime)
+	if completed {
+		currentTime = s.CompletedAt
 	}
 
-	// Calculate the SLA deadline based on business hours, weekends, and holidays
-	slaDeadline, err := s.calculateSLADeadline()
-	if err != nil {
-		// Handle the error (log it, return a special SLA result, etc.)
-		fmt.Println("Error calculating SLA deadline:", err)
-		return SLAResult{
-			IsWithinSLA: false,
-			Deadline:    time.Time{},
-			Remaining:   "N/A",
-			Overage:     "N/A",
-		}
+	// Build the calendar once for the deadline and every duration below
+	own := s.ownCalendar()
+	if err := s.validate(own); err != nil {
+		return SLAResult{}, err
 	}
+	calendar := s.clockCalendar(own)
 
-	// Initialize timeRemaining
-	var timeRemaining time.Duration
-	fmt.Println(timeRemaining)
+	// Calculate the SLA deadline based on business hours, weekends, holidays and pauses
+	slaDeadline, err := s.calculateSLADeadline(calendar, currentTime)
+	if err != nil {
+		return SLAResult{}, err
+	}
 
 	// Calculate the time difference
+	var timeRemaining time.Duration
 	if currentTime.Before(slaDeadline) {
 		timeRemaining = slaDeadline.Sub(currentTime)
 	}
@@ -134,93 +203,194 @@ func (s SLA) CheckSLA(currentTime time.Time) SLAResult {
 		overage = currentTime.Sub(slaDeadline)
 	}
 
-	// Calculate working time remaining
-	workingTimeRemaining := s.calculateWorkingTimeRemaining(currentTime, slaDeadline)
+	// Calculate working time remaining, elapsed business time, and the business time the clock has been stopped for so far
+	pauses := s.resolvePauses(currentTime)
+	workingTimeRemaining := calendar.runningTimeBetween(currentTime, slaDeadline, pauses)
+	elapsed := calendar.runningTimeBetween(s.StartTime, currentTime, pauses)
+	businessTime := calendar.runningTimeBetween(s.StartTime, currentTime, nil)
+	pausedTime := businessTime - elapsed
+	if pausedTime < 0 {
+		// currentTime is before the start, so nothing has elapsed or been paused
+		pausedTime = 0
+	}
 
-	// Convert durations to readable strings
-	remainingStr := formatDuration(timeRemaining)
-	overageStr := formatDuration(overage)
+	// The budget is the running time between the start and the deadline, whatever the time unit
+	var percentConsumed float64
+	if budget := calendar.runningTimeBetween(s.StartTime, slaDeadline, pauses); budget > 0 {
+		percentConsumed = float64(elapsed) / float64(budget) * 100
+	}
+
+	// Completion and breaches take precedence over pauses and the at-risk threshold
+	status := StatusOnTrack
+	switch {
+	case completed && isWithinSLA:
+		status = StatusMet
+	case !isWithinSLA:
+		status = StatusBreached
+	case s.isPaused(currentTime):
+		status = StatusPaused
+	case s.AtRisk.reached(percentConsumed, workingTimeRemaining):
+		status = StatusAtRisk
+	}
 
 	return SLAResult{
-		IsWithinSLA:          isWithinSLA,
-		Deadline:             slaDeadline,
-		Remaining:            remainingStr,
-		Overage:              overageStr,
-		WorkingTimeRemaining: workingTimeRemaining,
+		IsWithinSLA:                  isWithinSLA,
+		Status:                       status,
+		Deadline:                     slaDeadline,
+		Remaining:                    formatDuration(timeRemaining),
+		Overage:                      formatDuration(overage),
+		WorkingTimeRemaining:         formatDuration(workingTimeRemaining),
+		Elapsed:                      formatDuration(elapsed),
+		PausedTime:                   formatDuration(pausedTime),
+		PercentConsumed:              percentConsumed,
+		RemainingDuration:            timeRemaining,
+		OverageDuration:              overage,
+		WorkingTimeRemainingDuration: workingTimeRemaining,
+		ElapsedDuration:              elapsed,
+		PausedDuration:               pausedTime,
+	}, nil
+}
+
+// IsWithinSLA checks if the current time is within the SLA duration from the start time.
+// It reports false for an invalid SLA; use Deadline to find out why.
+func (s SLA) IsWithinSLA(currentTime time.Time) bool {
+	if s.isCompleted(currentTime) {
+		currentTime = s.CompletedAt
 	}
+
+	slaDeadline, err := s.Deadline(currentTime)
+	if err != nil {
+		return false
+	}
+
+	// Check if the current time is before the calculated SLA deadline
+	return currentTime.Before(slaDeadline)
 }
 
-// calculateWorkingTimeRemaining calculates the remaining working time considering business hours and days
-func (s SLA) calculateWorkingTimeRemaining(startTime, endTime time.Time) string {
-	remainingDuration := time.Duration(0)
-	currentTime := startTime
-
-	// Use pure functional iteration
-	for currentTime.Before(endTime) {
-		if s.isBusinessTime(currentTime) {
-			// Calculate the end of the current business day
-			endOfBusinessDay := time.Date(currentTime.Year(), currentTime.Month(), currentTime.Day(), s.BusinessHours.EndHour, 0, 0, 0, time.UTC)
-
-			adjustedStartTime := time.Date(
-				endOfBusinessDay.Year(),
-				endOfBusinessDay.Month(),
-				endOfBusinessDay.Day(),
-				s.BusinessHours.StartHour, 0, 0, 0,
-				endOfBusinessDay.Location(),
-			)
-
-			// If the end of business day exceeds the endTime, adjust it
-			if endOfBusinessDay.After(endTime) {
-				endOfBusinessDay = endTime
-			}
-
-			remainingDuration += endOfBusinessDay.Sub(adjustedStartTime)
-
-			currentTime = endOfBusinessDay
-		} else {
-			// Move to the next valid business day
-			currentTime = s.moveToNextBusinessDay(currentTime)
+// EvaluateNow evaluates the SLA at the current time of its Clock
+func (s SLA) EvaluateNow() (SLAResult, error) {
+	return s.Evaluate(now(s.Clock))
+}
+
+// isCompleted reports whether the work had been completed by currentTime
+func (s SLA) isCompleted(currentTime time.Time) bool {
+	return !s.CompletedAt.IsZero() && !s.CompletedAt.After(currentTime)
+}
+
+// CheckSLA checks if the current time is within the SLA duration and returns additional details.
+// An invalid SLA gives a result with "N/A" durations; use Evaluate to find out why.
+func (s SLA) CheckSLA(currentTime time.Time) SLAResult {
+	result, err := s.Evaluate(currentTime)
+	if err != nil {
+		return SLAResult{
+			IsWithinSLA: false,
+			Deadline:    time.Time{},
+			Remaining:   "N/A",
+			Overage:     "N/A",
 		}
 	}
+	return result
+}
 
-	return formatDuration(remainingDuration)
+// CheckNow checks the SLA at the current time of its Clock, like CheckSLA
+func (s SLA) CheckNow() SLAResult {
+	return s.CheckSLA(now(s.Clock))
 }
 
-func (s *SLA) isBusinessTime(t time.Time) bool {
-	return s.isValidDay(t) && s.isWithinBusinessHours(t) && !s.isHoliday(t)
+// MarshalJSON encodes the result with every duration as both a human-readable string and a number of seconds
+func (r SLAResult) MarshalJSON() ([]byte, error) {
+	// plainResult has the same fields without this method, so encoding it does not recurse
+	type plainResult SLAResult
+	return json.Marshal(struct {
+		plainResult
+		RemainingSeconds            float64 `json:"remainingSeconds"`
+		OverageSeconds              float64 `json:"overageSeconds"`
+		WorkingTimeRemainingSeconds float64 `json:"workingTimeRemainingSeconds"`
+		ElapsedSeconds              float64 `json:"elapsedSeconds"`
+		PausedTimeSeconds           float64 `json:"pausedTimeSeconds"`
+	}{
+		plainResult:                 plainResult(r),
+		RemainingSeconds:            r.RemainingDuration.Seconds(),
+		OverageSeconds:              r.OverageDuration.Seconds(),
+		WorkingTimeRemainingSeconds: r.WorkingTimeRemainingDuration.Seconds(),
+		ElapsedSeconds:              r.ElapsedDuration.Seconds(),
+		PausedTimeSeconds:           r.PausedDuration.Seconds(),
+	})
 }
 
-// calculateSLADeadline calculates the SLA deadline based on business hours, weekends, and holidays
-func (s SLA) calculateSLADeadline() (time.Time, error) {
-	remainingDuration, err := s.getSLADuration()
+// BusinessDurationBetween returns the exact business time between two instants, ignoring pauses.
+// Either instant may fall outside business hours, and the result is negative when to is before from.
+func (s SLA) BusinessDurationBetween(from, to time.Time) (time.Duration, error) {
+	own := s.ownCalendar()
+	if err := s.validateCalendar(own); err != nil {
+		return 0, err
+	}
+
+	if to.Before(from) {
+		return -s.clockCalendar(own).runningTimeBetween(to, from, nil), nil
+	}
+	return s.clockCalendar(own).runningTimeBetween(from, to, nil), nil
+}
+
+// SubtractBusinessTime returns the instant that lies the given amount of business time before t, ignoring pauses.
+// When the subtraction lands exactly on an opening time, the opening time is returned rather than the
+// previous closing time.
+func (s SLA) SubtractBusinessTime(t time.Time, d time.Duration) (time.Time, error) {
+	own := s.ownCalendar()
+	if err := s.validateCalendar(own); err != nil {
+		return time.Time{}, err
+	}
+	if d < 0 {
+		return time.Time{}, fmt.Errorf("%w: %v", ErrNegativeDuration, d)
+	}
+	return s.clockCalendar(own).subtractBusinessTime(t, d), nil
+}
+
+// LatestStartTime returns the latest instant work can start and still fit the SLA's length of business time
+// before the given deadline. It is the inverse of the deadline calculation, and ignores StartTime and pauses.
+// Weeks and business days step back to the same time of day, rolled back to the previous closing time when
+// the business is closed at that time.
+func (s SLA) LatestStartTime(deadline time.Time) (time.Time, error) {
+	own := s.ownCalendar()
+	if err := s.validate(own); err != nil {
+		return time.Time{}, err
+	}
+
+	length, err := s.length()
 	if err != nil {
-		return time.Time{}, err // Propagate the error
+		return time.Time{}, err
 	}
 
-	// Start from the initial SLA start time
-	currentTime := s.StartTime
+	calendar := s.clockCalendar(own)
+	start := calendar.subtractBusinessTime(deadline, length.duration)
+	if length.weeks > 0 || length.businessDays > 0 {
+		start = calendar.subtractDays(start, 7*length.weeks, length.businessDays)
+	}
+	return start, nil
+}
 
-	for remainingDuration > 0 {
-		// If it's a valid business day and hour, reduce the remaining SLA time
-		if s.isBusinessTime(currentTime) {
-			// Reduce remaining SLA time by one hour
-			if remainingDuration >= time.Hour {
-				remainingDuration -= time.Hour
-			} else {
-				break
-			}
-		}
+// calculateSLADeadline calculates the SLA deadline on calendar, the calendar its clock runs on, based on
+// business hours, weekends, holidays and pauses. Pauses that are still open at currentTime keep extending
+// the deadline until they are closed.
+func (s SLA) calculateSLADeadline(calendar *Calendar, currentTime time.Time) (time.Time, error) {
+	length, err := s.length()
+	if err != nil {
+		return time.Time{}, err // Propagate the error
+	}
 
-		// Move forward one hour to the next time slot
-		currentTime = currentTime.Add(time.Hour)
+	// Start from the initial SLA start time, in the calendar's time zone
+	pauses := s.resolvePauses(currentTime)
+	startTime := s.StartTime.In(calendar.location())
 
-		// If we've moved past business hours, skip to the start of the next business day
-		if !s.isWithinBusinessHours(currentTime) {
-			currentTime = s.moveToNextBusinessDay(currentTime)
-		}
+	// Weeks and business days land on the same time of day, and are then extended by the business
+	// time spent paused on the way there
+	deadline := startTime
+	if length.weeks > 0 || length.businessDays > 0 {
+		deadline = calendar.addDays(startTime, 7*length.weeks, length.businessDays)
 	}
+	paused := calendar.runningTimeBetween(startTime, deadline, nil) - calendar.runningTimeBetween(startTime, deadline, pauses)
 
-	return currentTime, nil
+	return calendar.addRunningTime(deadline, paused+length.duration, pauses), nil
 }
 
 // formatDuration converts time.Duration to a human-readable format
@@ -231,61 +401,81 @@ func formatDuration(d time.Duration) string {
 	return fmt.Sprintf("%02d:%02d:%02d", int(d.Hours()), int(d.Minutes())%60, int(d.Seconds())%60)
 }
 
-// moveToNextBusinessDay moves the given time to the start of the next business day
-func (s SLA) moveToNextBusinessDay(t time.Time) time.Time {
-	// Move to the start of the next day
-	t = time.Date(t.Year(), t.Month(), t.Day()+1, s.BusinessHours.StartHour, 0, 0, 0, t.Location())
-
-	// Keep moving forward until we hit a valid business day
-	for !s.isValidDay(t) || s.isHoliday(t) {
-		t = t.Add(24 * time.Hour)
+// length returns the SLA length, parsed from Length when it is set or converted from SLALength and TimeUnit
+func (s SLA) length() (slaLength, error) {
+	if s.Length != "" {
+		length, err := parseLength(s.Length)
+		if err != nil {
+			return slaLength{}, &ValidationError{Field: "Length", Err: err}
+		}
+		return length, nil
 	}
 
-	// Return the time set to the start of the next valid business day
-	return t
-}
-
-// getSLADuration converts the SLA length and time unit into a time.Duration
-func (s SLA) getSLADuration() (time.Duration, error) {
 	switch s.TimeUnit {
-	case "seconds":
-		return time.Duration(s.SLALength) * time.Second, nil
-	case "minutes":
-		return time.Duration(s.SLALength) * time.Minute, nil
-	case "hours":
-		return time.Duration(s.SLALength) * time.Hour, nil
-	case "days":
-		return time.Duration(s.SLALength) * 24 * time.Hour, nil
+	case TimeUnitSeconds:
+		return slaLength{duration: time.Duration(s.SLALength) * time.Second}, nil
+	case TimeUnitMinutes:
+		return slaLength{duration: time.Duration(s.SLALength) * time.Minute}, nil
+	case TimeUnitHours:
+		return slaLength{duration: time.Duration(s.SLALength) * time.Hour}, nil
+	case TimeUnitDays:
+		return slaLength{duration: time.Duration(s.SLALength) * 24 * time.Hour}, nil
+	case TimeUnitBusinessDays:
+		return slaLength{businessDays: s.SLALength}, nil
 	default:
-		return 0, fmt.Errorf("invalid time unit: %s", s.TimeUnit)
+		return slaLength{}, &ValidationError{Field: "TimeUnit", Err: fmt.Errorf("%w: %q", ErrInvalidTimeUnit, s.TimeUnit)}
 	}
 }
 
-// isValidDay checks if the given time falls on a valid day according to the SLA
-func (s SLA) isValidDay(t time.Time) bool {
-	for _, day := range s.ValidDays {
-		if t.Weekday() == day {
-			return true
-		}
+// ownCalendar returns the shared Calendar when one is set, or a calendar built from the SLA's own fields
+func (s SLA) ownCalendar() *Calendar {
+	if s.Calendar != nil {
+		return s.Calendar
 	}
-	return false
+	return s.inlineCalendar()
 }
 
-// isWithinBusinessHours checks if the given time is within the defined business hours
-func (s SLA) isWithinBusinessHours(t time.Time) bool {
-	hour := t.Hour()
-	return hour >= s.BusinessHours.StartHour && hour < s.BusinessHours.EndHour
+// inlineCalendar returns a calendar built from the SLA's own calendar fields, for a single evaluation
+func (s SLA) inlineCalendar() *Calendar {
+	return &Calendar{
+		BusinessHours:   s.BusinessHours,
+		BusinessWindows: s.BusinessWindows,
+		Schedule:        s.Schedule,
+		ValidDays:       s.ValidDays,
+		Holidays:        s.Holidays,
+		ReducedHours:    s.ReducedHours,
+		ExtraHours:      s.ExtraHours,
+		Closures:        s.Closures,
+		IgnoreHolidays:  s.IgnoreHolidays,
+		Location:        s.Location,
+		CountryCode:     s.CountryCode,
+		singleUse:       true,
+	}
 }
 
-// isHoliday checks if the given time falls on a holiday
-func (s SLA) isHoliday(t time.Time) bool {
-	if s.IgnoreHolidays {
-		return false
-	}
-	for _, holiday := range s.Holidays {
-		if t.Year() == holiday.Year() && t.YearDay() == holiday.YearDay() {
-			return true
+// calendar returns the calendar the SLA clock runs on
+func (s SLA) calendar() *Calendar {
+	return s.clockCalendar(s.ownCalendar())
+}
+
+// clockCalendar returns the calendar the SLA clock runs on given the SLA's own calendar, from ownCalendar.
+// In the calendar clock modes it is open all day, every day, skipping only holidays and closures with
+// ClockModeCalendarSkipHolidays.
+func (s SLA) clockCalendar(calendar *Calendar) *Calendar {
+	switch s.ClockMode {
+	case ClockModeCalendar:
+		return &Calendar{Schedule: calendarSchedule(), Location: calendar.Location, singleUse: true}
+	case ClockModeCalendarSkipHolidays:
+		return &Calendar{
+			Schedule:       calendarSchedule(),
+			Holidays:       calendar.Holidays,
+			ExtraHours:     calendar.ExtraHours,
+			Closures:       calendar.Closures,
+			IgnoreHolidays: calendar.IgnoreHolidays,
+			Location:       calendar.Location,
+			singleUse:      true,
 		}
+	default:
+		return calendar
 	}
-	return false
 }
@@ -1,6 +1,10 @@
 package slachecker
 
 import (
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
 	"testing"
 	"time"
 )
@@ -10,12 +14,9 @@ func setupSLAWithHolidays(holidays []time.Time) SLA {
 		StartTime: time.Date(2024, time.September, 1, 9, 0, 0, 0, time.UTC),
 		SLALength: 4,
 		TimeUnit:  "hours",
-		BusinessHours: struct {
-			StartHour int
-			EndHour   int
-		}{
-			StartHour: 9,
-			EndHour:   17,
+		BusinessHours: BusinessHours{
+			Start: NewTimeOfDay(9, 0),
+			End:   NewTimeOfDay(17, 0),
 		},
 		ValidDays: []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday},
 		Holidays:  holidays,
@@ -38,13 +39,17 @@ func TestCalculateWorkingTimeRemainingWithHolidays(t *testing.T) {
 	expectedWorkingTimeRemaining := "04:00:00"
 
 	// Call the calculateSLADeadline function
-	endTime, err := sla.calculateSLADeadline() // The deadline calculated from the SLA
+	endTime, err := sla.calculateSLADeadline(sla.calendar(), currentTime) // The deadline calculated from the SLA
 	if err != nil {
 		t.Fatalf("Error calculating SLA deadline: %v", err)
 	}
 
-	// Call the calculateWorkingTimeRemaining function
-	workingTimeRemaining := sla.calculateWorkingTimeRemaining(currentTime, endTime)
+	// Call the BusinessDurationBetween function
+	duration, err := sla.BusinessDurationBetween(currentTime, endTime)
+	if err != nil {
+		t.Fatalf("Error calculating business duration: %v", err)
+	}
+	workingTimeRemaining := formatDuration(duration)
 
 	// Check if the result matches the expected value
 	if workingTimeRemaining != expectedWorkingTimeRemaining {
@@ -254,3 +259,2067 @@ func TestCalculateWorkingTimeIgnoringHolidays(t *testing.T) {
 		t.Errorf("Expected overage time to be %v, but got %v", expectedOverage, result.Overage)
 	}
 }
+
+func TestCalculateSLADeadlinePrecision(t *testing.T) {
+	// Define holidays
+	holidays := []time.Time{
+		time.Date(2024, time.August, 26, 0, 0, 0, 0, time.UTC), // Holiday on Monday August 26, 2024
+	}
+
+	// Define common SLA configuration for the tests with holidays
+	sla := setupSLAWithHolidays(holidays)
+
+	tests := []struct {
+		name             string
+		startTime        time.Time
+		slaLength        int
+		timeUnit         string
+		expectedDeadline time.Time
+	}{
+		{
+			name:             "partial hour",
+			startTime:        time.Date(2024, time.August, 27, 9, 0, 0, 0, time.UTC), // Tuesday 9 AM
+			slaLength:        90,
+			timeUnit:         "minutes",
+			expectedDeadline: time.Date(2024, time.August, 27, 10, 30, 0, 0, time.UTC),
+		},
+		{
+			name:             "seconds",
+			startTime:        time.Date(2024, time.August, 27, 9, 0, 0, 0, time.UTC), // Tuesday 9 AM
+			slaLength:        3725,
+			timeUnit:         "seconds",
+			expectedDeadline: time.Date(2024, time.August, 27, 10, 2, 5, 0, time.UTC),
+		},
+		{
+			name:             "mid-window start",
+			startTime:        time.Date(2024, time.August, 27, 9, 40, 0, 0, time.UTC), // Tuesday 9:40 AM
+			slaLength:        4,
+			timeUnit:         "hours",
+			expectedDeadline: time.Date(2024, time.August, 27, 13, 40, 0, 0, time.UTC),
+		},
+		{
+			name:             "mid-window start carried to next day",
+			startTime:        time.Date(2024, time.August, 27, 15, 40, 15, 0, time.UTC), // Tuesday 3:40:15 PM
+			slaLength:        150,
+			timeUnit:         "minutes",
+			expectedDeadline: time.Date(2024, time.August, 28, 10, 10, 15, 0, time.UTC), // 1h19m45s Tuesday, rest Wednesday
+		},
+		{
+			name:             "deadline at closing time",
+			startTime:        time.Date(2024, time.August, 27, 13, 0, 0, 0, time.UTC), // Tuesday 1 PM
+			slaLength:        4,
+			timeUnit:         "hours",
+			expectedDeadline: time.Date(2024, time.August, 27, 17, 0, 0, 0, time.UTC),
+		},
+		{
+			name:             "start before opening",
+			startTime:        time.Date(2024, time.August, 27, 7, 15, 0, 0, time.UTC), // Tuesday 7:15 AM
+			slaLength:        45,
+			timeUnit:         "minutes",
+			expectedDeadline: time.Date(2024, time.August, 27, 9, 45, 0, 0, time.UTC),
+		},
+		{
+			name:             "start after closing",
+			startTime:        time.Date(2024, time.August, 27, 18, 30, 0, 0, time.UTC), // Tuesday 6:30 PM
+			slaLength:        30,
+			timeUnit:         "minutes",
+			expectedDeadline: time.Date(2024, time.August, 28, 9, 30, 0, 0, time.UTC),
+		},
+		{
+			name:             "start on weekend before holiday",
+			startTime:        time.Date(2024, time.August, 24, 11, 0, 0, 0, time.UTC), // Saturday 11 AM
+			slaLength:        10,
+			timeUnit:         "hours",
+			expectedDeadline: time.Date(2024, time.August, 28, 11, 0, 0, 0, time.UTC), // Monday is a holiday
+		},
+	}
+
+	for _, test := range tests {
+		// Set up SLA with the test parameters
+		sla.StartTime = test.startTime
+		sla.SLALength = test.slaLength
+		sla.TimeUnit = test.timeUnit
+
+		deadline, err := sla.calculateSLADeadline(sla.calendar(), test.startTime)
+		if err != nil {
+			t.Fatalf("%s: error calculating SLA deadline: %v", test.name, err)
+		}
+
+		if !deadline.Equal(test.expectedDeadline) {
+			t.Errorf("%s: expected deadline to be %v, but got %v", test.name, test.expectedDeadline, deadline)
+		}
+	}
+}
+
+func TestCheckSLAWithLocation(t *testing.T) {
+	london, err := time.LoadLocation("Europe/London")
+	if err != nil {
+		t.Fatalf("Error loading location: %v", err)
+	}
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatalf("Error loading location: %v", err)
+	}
+	sydney, err := time.LoadLocation("Australia/Sydney")
+	if err != nil {
+		t.Fatalf("Error loading location: %v", err)
+	}
+
+	tests := []struct {
+		name                string
+		location            *time.Location
+		holidays            []time.Time
+		startTime           time.Time
+		currentTime         time.Time
+		expectedIsWithinSLA bool
+		expectedDeadline    time.Time
+	}{
+		{
+			// Start before the New York desk opens, even though it is mid-morning in UTC
+			name:                "new york opening",
+			location:            newYork,
+			startTime:           time.Date(2024, time.August, 30, 12, 0, 0, 0, time.UTC),  // Friday 8 AM in New York
+			currentTime:         time.Date(2024, time.August, 30, 16, 30, 0, 0, time.UTC), // Friday 12:30 PM in New York
+			expectedIsWithinSLA: true,
+			expectedDeadline:    time.Date(2024, time.August, 30, 13, 0, 0, 0, newYork), // Friday 1 PM in New York
+		},
+		{
+			// Friday morning in Sydney is still Thursday night in UTC
+			name:                "sydney weekday",
+			location:            sydney,
+			startTime:           time.Date(2024, time.August, 29, 23, 0, 0, 0, time.UTC), // Friday 9 AM in Sydney
+			currentTime:         time.Date(2024, time.August, 30, 14, 0, 0, 0, sydney),   // Friday 2 PM in Sydney
+			expectedIsWithinSLA: false,
+			expectedDeadline:    time.Date(2024, time.August, 30, 13, 0, 0, 0, sydney),
+		},
+		{
+			// Summer bank holiday is skipped in London
+			name:                "london holiday",
+			location:            london,
+			holidays:            []time.Time{time.Date(2024, time.August, 26, 0, 0, 0, 0, time.UTC)},
+			startTime:           time.Date(2024, time.August, 23, 15, 0, 0, 0, time.UTC), // Friday 4 PM in London
+			currentTime:         time.Date(2024, time.August, 27, 10, 0, 0, 0, newYork),  // Tuesday 3 PM in London
+			expectedIsWithinSLA: false,
+			expectedDeadline:    time.Date(2024, time.August, 27, 12, 0, 0, 0, london),
+		},
+		{
+			// A UTC-midnight holiday date covers the whole Sydney calendar day
+			name:                "sydney holiday",
+			location:            sydney,
+			holidays:            []time.Time{time.Date(2024, time.September, 2, 0, 0, 0, 0, time.UTC)},
+			startTime:           time.Date(2024, time.August, 30, 16, 0, 0, 0, sydney),     // Friday 4 PM in Sydney
+			currentTime:         time.Date(2024, time.September, 2, 23, 0, 0, 0, time.UTC), // Tuesday 9 AM in Sydney
+			expectedIsWithinSLA: true,
+			expectedDeadline:    time.Date(2024, time.September, 3, 12, 0, 0, 0, sydney),
+		},
+	}
+
+	for _, test := range tests {
+		// Set up SLA with the test parameters
+		sla := setupSLAWithHolidays(test.holidays)
+		sla.Location = test.location
+		sla.StartTime = test.startTime
+
+		// Run the CheckSLA method
+		result := sla.CheckSLA(test.currentTime)
+
+		// Compare the result with the expected outcome
+		if result.IsWithinSLA != test.expectedIsWithinSLA {
+			t.Errorf("%s: expected IsWithinSLA to be %v, but got %v", test.name, test.expectedIsWithinSLA, result.IsWithinSLA)
+		}
+		if !result.Deadline.Equal(test.expectedDeadline) {
+			t.Errorf("%s: expected deadline to be %v, but got %v", test.name, test.expectedDeadline, result.Deadline)
+		}
+		if result.Deadline.Location() != test.location {
+			t.Errorf("%s: expected deadline in %v, but got %v", test.name, test.location, result.Deadline.Location())
+		}
+	}
+}
+
+func TestCalculateSLADeadlineAcrossDaylightSaving(t *testing.T) {
+	london, err := time.LoadLocation("Europe/London")
+	if err != nil {
+		t.Fatalf("Error loading location: %v", err)
+	}
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatalf("Error loading location: %v", err)
+	}
+
+	everyDay := []time.Weekday{
+		time.Sunday, time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday, time.Saturday,
+	}
+
+	tests := []struct {
+		name             string
+		location         *time.Location
+		validDays        []time.Weekday
+		startHour        int
+		endHour          int
+		startTime        time.Time
+		slaLength        int
+		expectedDeadline time.Time
+	}{
+		{
+			// Clocks go forward on Sunday 31 March 2024, Monday must still open at 9 AM
+			name:             "london spring forward weekend",
+			location:         london,
+			startHour:        9,
+			endHour:          17,
+			startTime:        time.Date(2024, time.March, 29, 16, 0, 0, 0, london), // Friday 4 PM GMT
+			slaLength:        4,
+			expectedDeadline: time.Date(2024, time.April, 1, 12, 0, 0, 0, london), // Monday 12 PM BST
+		},
+		{
+			// Clocks go back on Sunday 27 October 2024, Monday must still open at 9 AM
+			name:             "london fall back weekend",
+			location:         london,
+			startHour:        9,
+			endHour:          17,
+			startTime:        time.Date(2024, time.October, 25, 16, 0, 0, 0, london), // Friday 4 PM BST
+			slaLength:        4,
+			expectedDeadline: time.Date(2024, time.October, 28, 12, 0, 0, 0, london), // Monday 12 PM GMT
+		},
+		{
+			// Clocks go forward on Sunday 10 March 2024
+			name:             "new york spring forward weekend",
+			location:         newYork,
+			startHour:        9,
+			endHour:          17,
+			startTime:        time.Date(2024, time.March, 8, 16, 0, 0, 0, newYork), // Friday 4 PM EST
+			slaLength:        4,
+			expectedDeadline: time.Date(2024, time.March, 11, 12, 0, 0, 0, newYork), // Monday 12 PM EDT
+		},
+		{
+			// Sunday 31 March 2024 is a 23-hour day, 01:00-02:00 never happens in London
+			name:             "london 23 hour day",
+			location:         london,
+			validDays:        everyDay,
+			startHour:        0,
+			endHour:          24,
+			startTime:        time.Date(2024, time.March, 30, 20, 0, 0, 0, london), // Saturday 8 PM GMT
+			slaLength:        10,
+			expectedDeadline: time.Date(2024, time.March, 31, 7, 0, 0, 0, london), // Sunday 7 AM BST
+		},
+		{
+			// Sunday 27 October 2024 is a 25-hour day, 01:00-02:00 happens twice in London
+			name:             "london 25 hour day",
+			location:         london,
+			validDays:        everyDay,
+			startHour:        0,
+			endHour:          24,
+			startTime:        time.Date(2024, time.October, 26, 20, 0, 0, 0, london), // Saturday 8 PM BST
+			slaLength:        30,
+			expectedDeadline: time.Date(2024, time.October, 28, 1, 0, 0, 0, london), // Monday 1 AM GMT
+		},
+		{
+			// A daily 9 AM opening is unaffected by the transition overnight
+			name:             "london opening on transition day",
+			location:         london,
+			validDays:        everyDay,
+			startHour:        9,
+			endHour:          17,
+			startTime:        time.Date(2024, time.March, 30, 16, 0, 0, 0, london), // Saturday 4 PM GMT
+			slaLength:        4,
+			expectedDeadline: time.Date(2024, time.March, 31, 12, 0, 0, 0, london), // Sunday 12 PM BST
+		},
+	}
+
+	for _, test := range tests {
+		// Set up SLA with the test parameters
+		sla := setupSLAWithHolidays(nil)
+		sla.Location = test.location
+		if test.validDays != nil {
+			sla.ValidDays = test.validDays
+		}
+		sla.BusinessHours.Start = NewTimeOfDay(test.startHour, 0)
+		sla.BusinessHours.End = NewTimeOfDay(test.endHour, 0)
+		sla.StartTime = test.startTime
+		sla.SLALength = test.slaLength
+
+		deadline, err := sla.calculateSLADeadline(sla.calendar(), test.startTime)
+		if err != nil {
+			t.Fatalf("%s: error calculating SLA deadline: %v", test.name, err)
+		}
+
+		if !deadline.Equal(test.expectedDeadline) {
+			t.Errorf("%s: expected deadline to be %v, but got %v", test.name, test.expectedDeadline, deadline)
+		}
+	}
+}
+
+func TestCalculateSLADeadlineWithMidnightDaylightSaving(t *testing.T) {
+	// These zones change their clocks at midnight, so midnight never happens on the transition day
+	locations := map[string]*time.Location{}
+	for _, name := range []string{"America/Santiago", "America/Havana", "America/Asuncion"} {
+		location, err := time.LoadLocation(name)
+		if err != nil {
+			t.Fatalf("Error loading location: %v", err)
+		}
+		locations[name] = location
+	}
+	santiago, havana, asuncion := locations["America/Santiago"], locations["America/Havana"], locations["America/Asuncion"]
+
+	everyDay := []time.Weekday{
+		time.Sunday, time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday, time.Saturday,
+	}
+
+	tests := []struct {
+		name                string
+		location            *time.Location
+		validDays           []time.Weekday
+		startHour           int
+		endHour             int
+		startTime           time.Time
+		slaLength           int
+		expectedDeadline    time.Time
+		expectedLatestStart time.Time
+	}{
+		{
+			// Clocks go forward at midnight on Sunday 8 September 2024
+			name:                "santiago spring forward weekend",
+			location:            santiago,
+			startHour:           9,
+			endHour:             17,
+			startTime:           time.Date(2024, time.September, 6, 15, 0, 0, 0, santiago), // Friday 3 PM
+			slaLength:           4,
+			expectedDeadline:    time.Date(2024, time.September, 9, 11, 0, 0, 0, santiago), // Monday 11 AM
+			expectedLatestStart: time.Date(2024, time.September, 6, 15, 0, 0, 0, santiago),
+		},
+		{
+			// Clocks go forward at midnight on Sunday 6 October 2024
+			name:                "asuncion spring forward weekend",
+			location:            asuncion,
+			startHour:           9,
+			endHour:             17,
+			startTime:           time.Date(2024, time.October, 4, 16, 0, 0, 0, asuncion), // Friday 4 PM
+			slaLength:           4,
+			expectedDeadline:    time.Date(2024, time.October, 7, 12, 0, 0, 0, asuncion), // Monday 12 PM
+			expectedLatestStart: time.Date(2024, time.October, 4, 16, 0, 0, 0, asuncion),
+		},
+		{
+			// Sunday 10 March 2024 is a 23-hour day, 00:00-01:00 never happens in Havana
+			name:                "havana 23 hour day",
+			location:            havana,
+			validDays:           everyDay,
+			startHour:           0,
+			endHour:             24,
+			startTime:           time.Date(2024, time.March, 9, 20, 0, 0, 0, havana), // Saturday 8 PM CST
+			slaLength:           10,
+			expectedDeadline:    time.Date(2024, time.March, 10, 7, 0, 0, 0, havana), // Sunday 7 AM CDT
+			expectedLatestStart: time.Date(2024, time.March, 9, 20, 0, 0, 0, havana),
+		},
+		{
+			// A midnight opening on the transition day opens when the clocks jump to 1 AM
+			name:                "santiago midnight opening on transition day",
+			location:            santiago,
+			validDays:           everyDay,
+			startHour:           0,
+			endHour:             4,
+			startTime:           time.Date(2024, time.September, 7, 20, 0, 0, 0, santiago), // Saturday 8 PM
+			slaLength:           2,
+			expectedDeadline:    time.Date(2024, time.September, 8, 3, 0, 0, 0, santiago), // Sunday 3 AM
+			expectedLatestStart: time.Date(2024, time.September, 8, 1, 0, 0, 0, santiago), // Sunday's opening
+		},
+	}
+
+	for _, test := range tests {
+		sla := setupSLAWithHolidays(nil)
+		sla.Location = test.location
+		if test.validDays != nil {
+			sla.ValidDays = test.validDays
+		}
+		sla.BusinessHours.Start = NewTimeOfDay(test.startHour, 0)
+		sla.BusinessHours.End = NewTimeOfDay(test.endHour, 0)
+		sla.StartTime = test.startTime
+		sla.SLALength = test.slaLength
+
+		deadline, err := sla.Deadline(test.startTime)
+		if err != nil {
+			t.Fatalf("%s: error calculating SLA deadline: %v", test.name, err)
+		}
+		if !deadline.Equal(test.expectedDeadline) {
+			t.Errorf("%s: expected deadline to be %v, but got %v", test.name, test.expectedDeadline, deadline)
+		}
+
+		start, err := sla.LatestStartTime(test.expectedDeadline)
+		if err != nil {
+			t.Fatalf("%s: error calculating latest start: %v", test.name, err)
+		}
+		if !start.Equal(test.expectedLatestStart) {
+			t.Errorf("%s: expected latest start to be %v, but got %v", test.name, test.expectedLatestStart, start)
+		}
+	}
+}
+
+func TestBusinessDaysWithMidnightDaylightSaving(t *testing.T) {
+	santiago, err := time.LoadLocation("America/Santiago")
+	if err != nil {
+		t.Fatalf("Error loading location: %v", err)
+	}
+
+	// Open every day, so Sunday 8 September 2024, whose midnight never happens, is a business day
+	sla := setupSLAWithHolidays(nil)
+	sla.Location = santiago
+	sla.ValidDays = []time.Weekday{
+		time.Sunday, time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday, time.Saturday,
+	}
+	sla.SLALength = 2
+	sla.TimeUnit = TimeUnitBusinessDays
+
+	start := time.Date(2024, time.September, 7, 10, 0, 0, 0, santiago)    // Saturday 10 AM
+	expected := time.Date(2024, time.September, 9, 10, 0, 0, 0, santiago) // Monday 10 AM
+	sla.StartTime = start
+	deadline, err := sla.Deadline(start)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !deadline.Equal(expected) {
+		t.Errorf("Expected deadline to be %v, but got %v", expected, deadline)
+	}
+
+	latestStart, err := sla.LatestStartTime(expected)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !latestStart.Equal(start) {
+		t.Errorf("Expected latest start to be %v, but got %v", start, latestStart)
+	}
+}
+
+func TestMinuteGranularBusinessHours(t *testing.T) {
+	// Define the SLA for a desk open 08:30-17:45
+	sla := setupSLAWithHolidays(nil)
+	sla.BusinessHours = BusinessHours{Start: NewTimeOfDay(8, 30), End: NewTimeOfDay(17, 45)}
+
+	tests := []struct {
+		name                string
+		startTime           time.Time
+		slaLength           int
+		timeUnit            string
+		currentTime         time.Time
+		expectedIsWithinSLA bool
+		expectedDeadline    time.Time
+	}{
+		{
+			name:                "start before opening",
+			startTime:           time.Date(2024, time.August, 27, 8, 0, 0, 0, time.UTC), // Tuesday 8 AM
+			slaLength:           2,
+			timeUnit:            "hours",
+			currentTime:         time.Date(2024, time.August, 27, 10, 29, 0, 0, time.UTC),
+			expectedIsWithinSLA: true,
+			expectedDeadline:    time.Date(2024, time.August, 27, 10, 30, 0, 0, time.UTC),
+		},
+		{
+			name:                "carried over closing time",
+			startTime:           time.Date(2024, time.August, 27, 17, 0, 0, 0, time.UTC), // Tuesday 5 PM
+			slaLength:           60,
+			timeUnit:            "minutes",
+			currentTime:         time.Date(2024, time.August, 28, 8, 50, 0, 0, time.UTC),
+			expectedIsWithinSLA: false,
+			expectedDeadline:    time.Date(2024, time.August, 28, 8, 45, 0, 0, time.UTC), // 45 minutes Tuesday, 15 minutes Wednesday
+		},
+		{
+			name:                "full day",
+			startTime:           time.Date(2024, time.August, 30, 8, 30, 0, 0, time.UTC), // Friday 8:30 AM
+			slaLength:           555,
+			timeUnit:            "minutes",
+			currentTime:         time.Date(2024, time.August, 30, 17, 44, 0, 0, time.UTC),
+			expectedIsWithinSLA: true,
+			expectedDeadline:    time.Date(2024, time.August, 30, 17, 45, 0, 0, time.UTC),
+		},
+	}
+
+	for _, test := range tests {
+		// Set up SLA with the test parameters
+		sla.StartTime = test.startTime
+		sla.SLALength = test.slaLength
+		sla.TimeUnit = test.timeUnit
+
+		// Run the CheckSLA method
+		result := sla.CheckSLA(test.currentTime)
+
+		if result.IsWithinSLA != test.expectedIsWithinSLA {
+			t.Errorf("%s: expected IsWithinSLA to be %v, but got %v", test.name, test.expectedIsWithinSLA, result.IsWithinSLA)
+		}
+		if !result.Deadline.Equal(test.expectedDeadline) {
+			t.Errorf("%s: expected deadline to be %v, but got %v", test.name, test.expectedDeadline, result.Deadline)
+		}
+	}
+
+	// Business hours are honoured to the minute
+	if sla.calendar().isOpen(time.Date(2024, time.August, 27, 8, 29, 59, 0, time.UTC)) {
+		t.Error("Expected 08:29:59 to be outside business hours")
+	}
+	if !sla.calendar().isOpen(time.Date(2024, time.August, 27, 17, 44, 59, 0, time.UTC)) {
+		t.Error("Expected 17:44:59 to be within business hours")
+	}
+	if sla.calendar().isOpen(time.Date(2024, time.August, 27, 17, 45, 0, 0, time.UTC)) {
+		t.Error("Expected 17:45 to be outside business hours")
+	}
+}
+
+func TestValidateBusinessHours(t *testing.T) {
+	tests := []struct {
+		name          string
+		businessHours BusinessHours
+		expectError   bool
+	}{
+		{name: "minute precision", businessHours: BusinessHours{Start: NewTimeOfDay(8, 30), End: NewTimeOfDay(17, 45)}},
+		{name: "until midnight", businessHours: BusinessHours{Start: NewTimeOfDay(0, 0), End: NewTimeOfDay(24, 0)}},
+		{name: "overnight", businessHours: BusinessHours{Start: NewTimeOfDay(22, 0), End: NewTimeOfDay(6, 0)}},
+		{name: "overnight until midnight", businessHours: BusinessHours{Start: NewTimeOfDay(22, 0), End: NewTimeOfDay(0, 0)}},
+		{name: "empty window", businessHours: BusinessHours{Start: NewTimeOfDay(9, 0), End: NewTimeOfDay(9, 0)}, expectError: true},
+		{name: "invalid minute", businessHours: BusinessHours{Start: NewTimeOfDay(9, 75), End: NewTimeOfDay(17, 0)}, expectError: true},
+		{name: "start at midnight", businessHours: BusinessHours{Start: NewTimeOfDay(24, 0), End: NewTimeOfDay(24, 0)}, expectError: true},
+		{name: "past midnight", businessHours: BusinessHours{Start: NewTimeOfDay(9, 0), End: NewTimeOfDay(24, 30)}, expectError: true},
+	}
+
+	for _, test := range tests {
+		sla := setupSLAWithHolidays(nil)
+		sla.BusinessHours = test.businessHours
+
+		err := sla.Validate()
+		if test.expectError && err == nil {
+			t.Errorf("%s: expected a validation error, but got none", test.name)
+		}
+		if !test.expectError && err != nil {
+			t.Errorf("%s: unexpected validation error: %v", test.name, err)
+		}
+	}
+}
+
+func TestCheckSLAWithLunchBreak(t *testing.T) {
+	// Define the SLA with the clock stopped over lunch
+	sla := setupSLAWithHolidays(nil)
+	sla.BusinessWindows = []BusinessHours{
+		{Start: NewTimeOfDay(13, 0), End: NewTimeOfDay(17, 30)},
+		{Start: NewTimeOfDay(9, 0), End: NewTimeOfDay(12, 0)},
+	}
+
+	tests := []struct {
+		name                         string
+		startTime                    time.Time
+		slaLength                    int
+		timeUnit                     string
+		currentTime                  time.Time
+		expectedIsWithinSLA          bool
+		expectedDeadline             time.Time
+		expectedWorkingTimeRemaining string
+	}{
+		{
+			name:                         "spans lunch",
+			startTime:                    time.Date(2024, time.August, 27, 11, 0, 0, 0, time.UTC), // Tuesday 11 AM
+			slaLength:                    2,
+			timeUnit:                     "hours",
+			currentTime:                  time.Date(2024, time.August, 27, 11, 30, 0, 0, time.UTC),
+			expectedIsWithinSLA:          true,
+			expectedDeadline:             time.Date(2024, time.August, 27, 14, 0, 0, 0, time.UTC),
+			expectedWorkingTimeRemaining: "01:30:00", // 30 minutes before lunch, 1 hour after
+		},
+		{
+			name:                         "starts during lunch",
+			startTime:                    time.Date(2024, time.August, 27, 12, 15, 0, 0, time.UTC), // Tuesday 12:15 PM
+			slaLength:                    30,
+			timeUnit:                     "minutes",
+			currentTime:                  time.Date(2024, time.August, 27, 12, 45, 0, 0, time.UTC),
+			expectedIsWithinSLA:          true,
+			expectedDeadline:             time.Date(2024, time.August, 27, 13, 30, 0, 0, time.UTC),
+			expectedWorkingTimeRemaining: "00:30:00",
+		},
+		{
+			name:                         "carried to next morning",
+			startTime:                    time.Date(2024, time.August, 27, 16, 30, 0, 0, time.UTC), // Tuesday 4:30 PM
+			slaLength:                    3,
+			timeUnit:                     "hours",
+			currentTime:                  time.Date(2024, time.August, 28, 8, 0, 0, 0, time.UTC),
+			expectedIsWithinSLA:          true,
+			expectedDeadline:             time.Date(2024, time.August, 28, 11, 0, 0, 0, time.UTC),
+			expectedWorkingTimeRemaining: "02:00:00",
+		},
+		{
+			name:                         "breached after lunch",
+			startTime:                    time.Date(2024, time.August, 27, 10, 0, 0, 0, time.UTC), // Tuesday 10 AM
+			slaLength:                    3,
+			timeUnit:                     "hours",
+			currentTime:                  time.Date(2024, time.August, 27, 14, 30, 0, 0, time.UTC),
+			expectedIsWithinSLA:          false,
+			expectedDeadline:             time.Date(2024, time.August, 27, 14, 0, 0, 0, time.UTC),
+			expectedWorkingTimeRemaining: "00:00:00",
+		},
+	}
+
+	for _, test := range tests {
+		// Set up SLA with the test parameters
+		sla.StartTime = test.startTime
+		sla.SLALength = test.slaLength
+		sla.TimeUnit = test.timeUnit
+
+		// Run the CheckSLA method
+		result := sla.CheckSLA(test.currentTime)
+
+		if result.IsWithinSLA != test.expectedIsWithinSLA {
+			t.Errorf("%s: expected IsWithinSLA to be %v, but got %v", test.name, test.expectedIsWithinSLA, result.IsWithinSLA)
+		}
+		if !result.Deadline.Equal(test.expectedDeadline) {
+			t.Errorf("%s: expected deadline to be %v, but got %v", test.name, test.expectedDeadline, result.Deadline)
+		}
+		if result.WorkingTimeRemaining != test.expectedWorkingTimeRemaining {
+			t.Errorf("%s: expected workingTimeRemaining to be %s, but got %s", test.name, test.expectedWorkingTimeRemaining, result.WorkingTimeRemaining)
+		}
+	}
+
+	// The lunch break is not business time
+	if sla.calendar().isOpen(time.Date(2024, time.August, 27, 12, 30, 0, 0, time.UTC)) {
+		t.Error("Expected 12:30 to be outside business hours")
+	}
+}
+
+func TestValidateBusinessWindows(t *testing.T) {
+	tests := []struct {
+		name        string
+		windows     []BusinessHours
+		expectError bool
+	}{
+		{
+			name: "lunch break",
+			windows: []BusinessHours{
+				{Start: NewTimeOfDay(9, 0), End: NewTimeOfDay(12, 0)},
+				{Start: NewTimeOfDay(13, 0), End: NewTimeOfDay(17, 30)},
+			},
+		},
+		{
+			name: "adjacent windows",
+			windows: []BusinessHours{
+				{Start: NewTimeOfDay(12, 0), End: NewTimeOfDay(17, 0)},
+				{Start: NewTimeOfDay(9, 0), End: NewTimeOfDay(12, 0)},
+			},
+		},
+		{
+			name: "overlapping windows",
+			windows: []BusinessHours{
+				{Start: NewTimeOfDay(9, 0), End: NewTimeOfDay(12, 0)},
+				{Start: NewTimeOfDay(11, 30), End: NewTimeOfDay(17, 0)},
+			},
+			expectError: true,
+		},
+		{
+			name: "nested windows",
+			windows: []BusinessHours{
+				{Start: NewTimeOfDay(9, 0), End: NewTimeOfDay(17, 0)},
+				{Start: NewTimeOfDay(10, 0), End: NewTimeOfDay(11, 0)},
+			},
+			expectError: true,
+		},
+		{
+			name: "invalid window",
+			windows: []BusinessHours{
+				{Start: NewTimeOfDay(9, 0), End: NewTimeOfDay(12, 0)},
+				{Start: NewTimeOfDay(17, 0), End: NewTimeOfDay(17, 0)},
+			},
+			expectError: true,
+		},
+		{
+			name: "overnight window overlaps next morning",
+			windows: []BusinessHours{
+				{Start: NewTimeOfDay(9, 0), End: NewTimeOfDay(12, 0)},
+				{Start: NewTimeOfDay(17, 0), End: NewTimeOfDay(10, 0)},
+			},
+			expectError: true,
+		},
+	}
+
+	for _, test := range tests {
+		sla := setupSLAWithHolidays(nil)
+		sla.BusinessWindows = test.windows
+
+		err := sla.Validate()
+		if test.expectError && err == nil {
+			t.Errorf("%s: expected a validation error, but got none", test.name)
+		}
+		if !test.expectError && err != nil {
+			t.Errorf("%s: unexpected validation error: %v", test.name, err)
+		}
+	}
+}
+
+func TestCheckSLAWithWeeklySchedule(t *testing.T) {
+	weekday := []BusinessHours{{Start: NewTimeOfDay(8, 0), End: NewTimeOfDay(18, 0)}}
+
+	// Define the SLA for a retail customer open on Saturday mornings
+	sla := setupSLAWithHolidays(nil)
+	sla.Schedule = WeeklySchedule{
+		time.Monday:    weekday,
+		time.Tuesday:   weekday,
+		time.Wednesday: weekday,
+		time.Thursday:  weekday,
+		time.Friday:    weekday,
+		time.Saturday:  {{Start: NewTimeOfDay(9, 0), End: NewTimeOfDay(13, 0)}},
+	}
+
+	tests := []struct {
+		name                string
+		startTime           time.Time
+		slaLength           int
+		currentTime         time.Time
+		expectedIsWithinSLA bool
+		expectedDeadline    time.Time
+	}{
+		{
+			name:                "friday into saturday",
+			startTime:           time.Date(2024, time.August, 30, 17, 0, 0, 0, time.UTC), // Friday 5 PM
+			slaLength:           4,
+			currentTime:         time.Date(2024, time.August, 31, 11, 0, 0, 0, time.UTC),
+			expectedIsWithinSLA: true,
+			expectedDeadline:    time.Date(2024, time.August, 31, 12, 0, 0, 0, time.UTC), // Saturday 12 PM
+		},
+		{
+			name:                "saturday into monday",
+			startTime:           time.Date(2024, time.August, 31, 12, 0, 0, 0, time.UTC), // Saturday 12 PM
+			slaLength:           3,
+			currentTime:         time.Date(2024, time.September, 2, 10, 30, 0, 0, time.UTC),
+			expectedIsWithinSLA: false,
+			expectedDeadline:    time.Date(2024, time.September, 2, 10, 0, 0, 0, time.UTC), // Monday 10 AM
+		},
+		{
+			name:                "sunday start",
+			startTime:           time.Date(2024, time.September, 1, 10, 0, 0, 0, time.UTC), // Sunday 10 AM
+			slaLength:           1,
+			currentTime:         time.Date(2024, time.September, 2, 8, 30, 0, 0, time.UTC),
+			expectedIsWithinSLA: true,
+			expectedDeadline:    time.Date(2024, time.September, 2, 9, 0, 0, 0, time.UTC), // Monday 9 AM
+		},
+	}
+
+	for _, test := range tests {
+		// Set up SLA with the test parameters
+		sla.StartTime = test.startTime
+		sla.SLALength = test.slaLength
+
+		// Run the CheckSLA method
+		result := sla.CheckSLA(test.currentTime)
+
+		if result.IsWithinSLA != test.expectedIsWithinSLA {
+			t.Errorf("%s: expected IsWithinSLA to be %v, but got %v", test.name, test.expectedIsWithinSLA, result.IsWithinSLA)
+		}
+		if !result.Deadline.Equal(test.expectedDeadline) {
+			t.Errorf("%s: expected deadline to be %v, but got %v", test.name, test.expectedDeadline, result.Deadline)
+		}
+	}
+}
+
+func TestScheduleExpandsValidDays(t *testing.T) {
+	// ValidDays and BusinessWindows are shorthand for a weekly schedule
+	sla := setupSLAWithHolidays(nil)
+	sla.ValidDays = []time.Weekday{time.Monday, time.Saturday}
+	sla.BusinessWindows = []BusinessHours{
+		{Start: NewTimeOfDay(13, 0), End: NewTimeOfDay(17, 0)},
+		{Start: NewTimeOfDay(9, 0), End: NewTimeOfDay(12, 0)},
+	}
+
+	schedule := sla.calendar().schedule()
+	if len(schedule) != 2 {
+		t.Fatalf("Expected 2 scheduled days, but got %d", len(schedule))
+	}
+	for _, day := range sla.ValidDays {
+		windows := schedule.windowsOn(day)
+		if len(windows) != 2 || windows[0] != sla.BusinessWindows[1] || windows[1] != sla.BusinessWindows[0] {
+			t.Errorf("Expected %v to have windows 09:00-12:00 and 13:00-17:00, but got %v", day, windows)
+		}
+	}
+	if windows := schedule.windowsOn(time.Sunday); len(windows) != 0 {
+		t.Errorf("Expected Sunday to be closed, but got %v", windows)
+	}
+}
+
+func TestCheckSLAWithOvernightShift(t *testing.T) {
+	// Define holidays
+	holidays := []time.Time{
+		time.Date(2024, time.August, 26, 0, 0, 0, 0, time.UTC), // Holiday on Monday August 26, 2024
+	}
+
+	// Define the SLA for a night operations team working 22:00-06:00, with shifts starting Monday to Friday
+	sla := setupSLAWithHolidays(holidays)
+	sla.BusinessHours = BusinessHours{Start: NewTimeOfDay(22, 0), End: NewTimeOfDay(6, 0)}
+
+	tests := []struct {
+		name                         string
+		startTime                    time.Time
+		slaLength                    int
+		currentTime                  time.Time
+		expectedIsWithinSLA          bool
+		expectedDeadline             time.Time
+		expectedWorkingTimeRemaining string
+	}{
+		{
+			name:                         "within one shift",
+			startTime:                    time.Date(2024, time.August, 27, 23, 0, 0, 0, time.UTC), // Tuesday 11 PM
+			slaLength:                    4,
+			currentTime:                  time.Date(2024, time.August, 28, 1, 0, 0, 0, time.UTC),
+			expectedIsWithinSLA:          true,
+			expectedDeadline:             time.Date(2024, time.August, 28, 3, 0, 0, 0, time.UTC),
+			expectedWorkingTimeRemaining: "02:00:00",
+		},
+		{
+			name:                         "friday shift runs into saturday",
+			startTime:                    time.Date(2024, time.August, 31, 3, 0, 0, 0, time.UTC), // Saturday 3 AM
+			slaLength:                    1,
+			currentTime:                  time.Date(2024, time.August, 31, 3, 30, 0, 0, time.UTC),
+			expectedIsWithinSLA:          true,
+			expectedDeadline:             time.Date(2024, time.August, 31, 4, 0, 0, 0, time.UTC),
+			expectedWorkingTimeRemaining: "00:30:00",
+		},
+		{
+			name:                         "carried over the weekend",
+			startTime:                    time.Date(2024, time.August, 30, 23, 0, 0, 0, time.UTC), // Friday 11 PM
+			slaLength:                    10,
+			currentTime:                  time.Date(2024, time.September, 2, 12, 0, 0, 0, time.UTC),
+			expectedIsWithinSLA:          true,
+			expectedDeadline:             time.Date(2024, time.September, 3, 1, 0, 0, 0, time.UTC), // 7 hours Friday night, 3 hours Monday night
+			expectedWorkingTimeRemaining: "03:00:00",
+		},
+		{
+			name:                         "sunday night is closed",
+			startTime:                    time.Date(2024, time.September, 2, 3, 0, 0, 0, time.UTC), // Monday 3 AM
+			slaLength:                    1,
+			currentTime:                  time.Date(2024, time.September, 2, 22, 30, 0, 0, time.UTC),
+			expectedIsWithinSLA:          true,
+			expectedDeadline:             time.Date(2024, time.September, 2, 23, 0, 0, 0, time.UTC),
+			expectedWorkingTimeRemaining: "00:30:00",
+		},
+		{
+			name:                         "holiday shift is closed",
+			startTime:                    time.Date(2024, time.August, 26, 23, 30, 0, 0, time.UTC), // Monday 11:30 PM, a holiday
+			slaLength:                    2,
+			currentTime:                  time.Date(2024, time.August, 27, 5, 0, 0, 0, time.UTC),
+			expectedIsWithinSLA:          true,
+			expectedDeadline:             time.Date(2024, time.August, 28, 0, 0, 0, 0, time.UTC), // Tuesday night shift
+			expectedWorkingTimeRemaining: "02:00:00",
+		},
+	}
+
+	for _, test := range tests {
+		// Set up SLA with the test parameters
+		sla.StartTime = test.startTime
+		sla.SLALength = test.slaLength
+
+		// Run the CheckSLA method
+		result := sla.CheckSLA(test.currentTime)
+
+		if result.IsWithinSLA != test.expectedIsWithinSLA {
+			t.Errorf("%s: expected IsWithinSLA to be %v, but got %v", test.name, test.expectedIsWithinSLA, result.IsWithinSLA)
+		}
+		if !result.Deadline.Equal(test.expectedDeadline) {
+			t.Errorf("%s: expected deadline to be %v, but got %v", test.name, test.expectedDeadline, result.Deadline)
+		}
+		if result.WorkingTimeRemaining != test.expectedWorkingTimeRemaining {
+			t.Errorf("%s: expected workingTimeRemaining to be %s, but got %s", test.name, test.expectedWorkingTimeRemaining, result.WorkingTimeRemaining)
+		}
+	}
+
+	// Early hours belong to the shift that started the night before
+	if !sla.calendar().isOpen(time.Date(2024, time.August, 31, 5, 0, 0, 0, time.UTC)) {
+		t.Error("Expected Saturday 5 AM to be within Friday's shift")
+	}
+	if sla.calendar().isOpen(time.Date(2024, time.September, 1, 5, 0, 0, 0, time.UTC)) {
+		t.Error("Expected Sunday 5 AM to be outside business hours")
+	}
+	if sla.calendar().isOpen(time.Date(2024, time.August, 27, 5, 0, 0, 0, time.UTC)) {
+		t.Error("Expected Tuesday 5 AM to be outside business hours after a holiday shift")
+	}
+}
+
+func TestCheckSLAWithPauses(t *testing.T) {
+	// Define common SLA configuration for the tests with pauses
+	sla := setupSLAWithHolidays(nil)
+	sla.SLALength = 4
+	sla.TimeUnit = "hours"
+
+	tests := []struct {
+		name                         string
+		startTime                    time.Time
+		pauses                       []Pause
+		currentTime                  time.Time
+		expectedIsWithinSLA          bool
+		expectedDeadline             time.Time
+		expectedWorkingTimeRemaining string
+		expectedPausedTime           string
+	}{
+		{
+			name:      "closed pause",
+			startTime: time.Date(2024, time.August, 27, 9, 0, 0, 0, time.UTC), // Tuesday 9 AM
+			pauses: []Pause{
+				{Start: time.Date(2024, time.August, 27, 10, 0, 0, 0, time.UTC), End: time.Date(2024, time.August, 27, 11, 30, 0, 0, time.UTC)},
+			},
+			currentTime:                  time.Date(2024, time.August, 27, 14, 0, 0, 0, time.UTC),
+			expectedIsWithinSLA:          true,
+			expectedDeadline:             time.Date(2024, time.August, 27, 14, 30, 0, 0, time.UTC),
+			expectedWorkingTimeRemaining: "00:30:00",
+			expectedPausedTime:           "01:30:00",
+		},
+		{
+			name:      "pause over closing time",
+			startTime: time.Date(2024, time.August, 27, 14, 0, 0, 0, time.UTC), // Tuesday 2 PM
+			pauses: []Pause{
+				{Start: time.Date(2024, time.August, 27, 16, 0, 0, 0, time.UTC), End: time.Date(2024, time.August, 28, 10, 0, 0, 0, time.UTC)},
+			},
+			currentTime:                  time.Date(2024, time.August, 28, 11, 0, 0, 0, time.UTC),
+			expectedIsWithinSLA:          true,
+			expectedDeadline:             time.Date(2024, time.August, 28, 12, 0, 0, 0, time.UTC), // Only 2 business hours were paused
+			expectedWorkingTimeRemaining: "01:00:00",
+			expectedPausedTime:           "02:00:00",
+		},
+		{
+			name:      "open pause",
+			startTime: time.Date(2024, time.August, 27, 9, 0, 0, 0, time.UTC), // Tuesday 9 AM
+			pauses: []Pause{
+				{Start: time.Date(2024, time.August, 27, 10, 0, 0, 0, time.UTC)},
+			},
+			currentTime:                  time.Date(2024, time.August, 27, 12, 0, 0, 0, time.UTC),
+			expectedIsWithinSLA:          true,
+			expectedDeadline:             time.Date(2024, time.August, 27, 15, 0, 0, 0, time.UTC), // Extended for as long as the pause stays open
+			expectedWorkingTimeRemaining: "03:00:00",
+			expectedPausedTime:           "02:00:00",
+		},
+		{
+			name:      "pause from before the start",
+			startTime: time.Date(2024, time.August, 27, 9, 0, 0, 0, time.UTC), // Tuesday 9 AM
+			pauses: []Pause{
+				{Start: time.Date(2024, time.August, 26, 16, 0, 0, 0, time.UTC), End: time.Date(2024, time.August, 27, 10, 0, 0, 0, time.UTC)},
+			},
+			currentTime:                  time.Date(2024, time.August, 27, 15, 0, 0, 0, time.UTC),
+			expectedIsWithinSLA:          false,
+			expectedDeadline:             time.Date(2024, time.August, 27, 14, 0, 0, 0, time.UTC),
+			expectedWorkingTimeRemaining: "00:00:00",
+			expectedPausedTime:           "01:00:00",
+		},
+		{
+			name:      "overlapping pauses",
+			startTime: time.Date(2024, time.August, 27, 9, 0, 0, 0, time.UTC), // Tuesday 9 AM
+			pauses: []Pause{
+				{Start: time.Date(2024, time.August, 27, 10, 30, 0, 0, time.UTC), End: time.Date(2024, time.August, 27, 12, 0, 0, 0, time.UTC)},
+				{Start: time.Date(2024, time.August, 27, 10, 0, 0, 0, time.UTC), End: time.Date(2024, time.August, 27, 11, 0, 0, 0, time.UTC)},
+			},
+			currentTime:                  time.Date(2024, time.August, 27, 13, 0, 0, 0, time.UTC),
+			expectedIsWithinSLA:          true,
+			expectedDeadline:             time.Date(2024, time.August, 27, 15, 0, 0, 0, time.UTC),
+			expectedWorkingTimeRemaining: "02:00:00",
+			expectedPausedTime:           "02:00:00",
+		},
+	}
+
+	for _, test := range tests {
+		// Set up SLA with the test parameters
+		sla.StartTime = test.startTime
+		sla.Pauses = test.pauses
+
+		// Run the CheckSLA method
+		result := sla.CheckSLA(test.currentTime)
+
+		if result.IsWithinSLA != test.expectedIsWithinSLA {
+			t.Errorf("%s: expected IsWithinSLA to be %v, but got %v", test.name, test.expectedIsWithinSLA, result.IsWithinSLA)
+		}
+		if !result.Deadline.Equal(test.expectedDeadline) {
+			t.Errorf("%s: expected deadline to be %v, but got %v", test.name, test.expectedDeadline, result.Deadline)
+		}
+		if result.WorkingTimeRemaining != test.expectedWorkingTimeRemaining {
+			t.Errorf("%s: expected workingTimeRemaining to be %s, but got %s", test.name, test.expectedWorkingTimeRemaining, result.WorkingTimeRemaining)
+		}
+		if result.PausedTime != test.expectedPausedTime {
+			t.Errorf("%s: expected pausedTime to be %s, but got %s", test.name, test.expectedPausedTime, result.PausedTime)
+		}
+	}
+}
+
+func TestValidateSentinelErrors(t *testing.T) {
+	tests := []struct {
+		name          string
+		modify        func(sla *SLA)
+		expectedErr   error
+		expectedField string
+	}{
+		{name: "SLA length", modify: func(sla *SLA) { sla.SLALength = 0 }, expectedErr: ErrInvalidLength, expectedField: "SLALength"},
+		{
+			name:          "completed before start",
+			modify:        func(sla *SLA) { sla.CompletedAt = sla.StartTime.Add(-time.Hour) },
+			expectedErr:   ErrInvalidCompletion,
+			expectedField: "CompletedAt",
+		},
+		{
+			name:          "at-risk percentage",
+			modify:        func(sla *SLA) { sla.AtRisk.PercentConsumed = 120 },
+			expectedErr:   ErrInvalidThreshold,
+			expectedField: "AtRisk.PercentConsumed",
+		},
+		{
+			name:          "at-risk remaining time",
+			modify:        func(sla *SLA) { sla.AtRisk.Remaining = -time.Minute },
+			expectedErr:   ErrInvalidThreshold,
+			expectedField: "AtRisk.Remaining",
+		},
+		{name: "time unit", modify: func(sla *SLA) { sla.TimeUnit = "fortnights" }, expectedErr: ErrInvalidTimeUnit, expectedField: "TimeUnit"},
+		{name: "clock mode", modify: func(sla *SLA) { sla.ClockMode = "wallClock" }, expectedErr: ErrInvalidClockMode, expectedField: "ClockMode"},
+		{name: "length", modify: func(sla *SLA) { sla.Length = "P1M" }, expectedErr: ErrInvalidDuration, expectedField: "Length"},
+		{name: "country code", modify: func(sla *SLA) { sla.CountryCode = "gbr" }, expectedErr: ErrInvalidCountryCode, expectedField: "CountryCode"},
+		{
+			name:          "business hours",
+			modify:        func(sla *SLA) { sla.BusinessHours.End = NewTimeOfDay(25, 0) },
+			expectedErr:   ErrInvalidBusinessHours,
+			expectedField: "BusinessHours",
+		},
+		{
+			name: "overlapping windows",
+			modify: func(sla *SLA) {
+				sla.BusinessWindows = []BusinessHours{
+					{Start: NewTimeOfDay(9, 0), End: NewTimeOfDay(12, 0)},
+					{Start: NewTimeOfDay(11, 0), End: NewTimeOfDay(17, 0)},
+				}
+			},
+			expectedErr:   ErrOverlappingWindows,
+			expectedField: "BusinessWindows",
+		},
+		{
+			name: "schedule",
+			modify: func(sla *SLA) {
+				sla.Schedule = WeeklySchedule{time.Monday: {{Start: NewTimeOfDay(9, 0), End: NewTimeOfDay(9, 0)}}}
+			},
+			expectedErr:   ErrInvalidBusinessHours,
+			expectedField: "Schedule[Monday]",
+		},
+		{name: "no valid days", modify: func(sla *SLA) { sla.ValidDays = nil }, expectedErr: ErrNoValidDays, expectedField: "ValidDays"},
+		{
+			name:          "invalid day",
+			modify:        func(sla *SLA) { sla.ValidDays = append(sla.ValidDays, time.Weekday(9)) },
+			expectedErr:   ErrInvalidDay,
+			expectedField: "ValidDays[5]",
+		},
+		{
+			name:          "holiday",
+			modify:        func(sla *SLA) { sla.Holidays = []time.Time{{}} },
+			expectedErr:   ErrInvalidHoliday,
+			expectedField: "Holidays[0]",
+		},
+		{
+			name:          "reduced hours date",
+			modify:        func(sla *SLA) { sla.ReducedHours = []DayHours{{Hours: []BusinessHours{sla.BusinessHours}}} },
+			expectedErr:   ErrInvalidHoliday,
+			expectedField: "ReducedHours[0].Date",
+		},
+		{
+			name: "reduced hours windows",
+			modify: func(sla *SLA) {
+				sla.ReducedHours = []DayHours{{Date: sla.StartTime, Hours: []BusinessHours{{Start: NewTimeOfDay(13, 0), End: NewTimeOfDay(13, 0)}}}}
+			},
+			expectedErr:   ErrInvalidBusinessHours,
+			expectedField: "ReducedHours[0].Hours",
+		},
+		{
+			name:          "extra hours date",
+			modify:        func(sla *SLA) { sla.ExtraHours = []DayHours{{Hours: []BusinessHours{sla.BusinessHours}}} },
+			expectedErr:   ErrInvalidHoliday,
+			expectedField: "ExtraHours[0].Date",
+		},
+		{
+			name:          "closure",
+			modify:        func(sla *SLA) { sla.Closures = []Closure{{Start: sla.StartTime, End: sla.StartTime.Add(-time.Hour)}} },
+			expectedErr:   ErrInvalidClosure,
+			expectedField: "Closures[0]",
+		},
+		{
+			name:          "pause",
+			modify:        func(sla *SLA) { sla.Pauses = []Pause{{End: sla.StartTime}} },
+			expectedErr:   ErrInvalidPause,
+			expectedField: "Pauses[0]",
+		},
+	}
+
+	for _, test := range tests {
+		sla := setupSLAWithHolidays(nil)
+		test.modify(&sla)
+
+		err := sla.Validate()
+		if !errors.Is(err, test.expectedErr) {
+			t.Errorf("%s: expected error %v, but got %v", test.name, test.expectedErr, err)
+		}
+
+		var validationErr *ValidationError
+		if !errors.As(err, &validationErr) {
+			t.Errorf("%s: expected a *ValidationError, but got %T", test.name, err)
+		} else if validationErr.Field != test.expectedField {
+			t.Errorf("%s: expected field %q, but got %q", test.name, test.expectedField, validationErr.Field)
+		}
+	}
+}
+
+func TestEvaluateReturnsErrors(t *testing.T) {
+	// Capture anything written to stdout while evaluating an invalid SLA
+	stdout := os.Stdout
+	reader, writer, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("Error creating pipe: %v", err)
+	}
+	os.Stdout = writer
+
+	sla := setupSLAWithHolidays(nil)
+	sla.TimeUnit = "fortnights"
+	currentTime := time.Date(2024, time.September, 2, 10, 0, 0, 0, time.UTC)
+
+	_, evaluateErr := sla.Evaluate(currentTime)
+	_, deadlineErr := sla.Deadline(currentTime)
+	result := sla.CheckSLA(currentTime)
+	isWithinSLA := sla.IsWithinSLA(currentTime)
+
+	os.Stdout = stdout
+	writer.Close()
+	output, _ := io.ReadAll(reader)
+
+	if !errors.Is(evaluateErr, ErrInvalidTimeUnit) {
+		t.Errorf("Expected Evaluate to return %v, but got %v", ErrInvalidTimeUnit, evaluateErr)
+	}
+	if !errors.Is(deadlineErr, ErrInvalidTimeUnit) {
+		t.Errorf("Expected Deadline to return %v, but got %v", ErrInvalidTimeUnit, deadlineErr)
+	}
+	if result.IsWithinSLA || result.Remaining != "N/A" || result.Overage != "N/A" {
+		t.Errorf("Expected an N/A result from CheckSLA, but got %+v", result)
+	}
+	if isWithinSLA {
+		t.Error("Expected IsWithinSLA to be false for an invalid SLA")
+	}
+	if len(output) != 0 {
+		t.Errorf("Expected nothing to be written to stdout, but got %q", output)
+	}
+
+	// A valid SLA evaluates without error
+	sla.TimeUnit = "hours"
+	if _, err := sla.Evaluate(currentTime); err != nil {
+		t.Errorf("Unexpected error evaluating SLA: %v", err)
+	}
+}
+
+func TestCheckSLADurations(t *testing.T) {
+	// Define common SLA configuration for the tests
+	sla := setupSLAWithHolidays(nil)
+	sla.StartTime = time.Date(2024, time.August, 27, 9, 0, 0, 0, time.UTC) // Tuesday 9 AM
+	sla.SLALength = 4
+	sla.TimeUnit = "hours"
+
+	tests := []struct {
+		name                         string
+		pauses                       []Pause
+		currentTime                  time.Time
+		expectedRemaining            time.Duration
+		expectedOverage              time.Duration
+		expectedWorkingTimeRemaining time.Duration
+		expectedElapsed              time.Duration
+		expectedPaused               time.Duration
+		expectedPercentConsumed      float64
+	}{
+		{
+			name: "on track with a pause",
+			pauses: []Pause{
+				{Start: time.Date(2024, time.August, 27, 10, 0, 0, 0, time.UTC), End: time.Date(2024, time.August, 27, 10, 30, 0, 0, time.UTC)},
+			},
+			currentTime:                  time.Date(2024, time.August, 27, 12, 0, 0, 0, time.UTC),
+			expectedRemaining:            90 * time.Minute,
+			expectedWorkingTimeRemaining: 90 * time.Minute,
+			expectedElapsed:              150 * time.Minute,
+			expectedPaused:               30 * time.Minute,
+			expectedPercentConsumed:      62.5,
+		},
+		{
+			name:                         "before the start",
+			currentTime:                  time.Date(2024, time.August, 26, 20, 0, 0, 0, time.UTC), // Before the start
+			expectedRemaining:            17 * time.Hour,
+			expectedWorkingTimeRemaining: 4 * time.Hour,
+		},
+		{
+			name:                    "breached",
+			currentTime:             time.Date(2024, time.August, 27, 15, 0, 0, 0, time.UTC),
+			expectedOverage:         2 * time.Hour,
+			expectedElapsed:         6 * time.Hour,
+			expectedPercentConsumed: 150,
+		},
+	}
+
+	for _, test := range tests {
+		// Set up SLA with the test parameters
+		sla.Pauses = test.pauses
+
+		result, err := sla.Evaluate(test.currentTime)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", test.name, err)
+		}
+
+		if result.RemainingDuration != test.expectedRemaining {
+			t.Errorf("%s: expected remaining %v, but got %v", test.name, test.expectedRemaining, result.RemainingDuration)
+		}
+		if result.OverageDuration != test.expectedOverage {
+			t.Errorf("%s: expected overage %v, but got %v", test.name, test.expectedOverage, result.OverageDuration)
+		}
+		if result.WorkingTimeRemainingDuration != test.expectedWorkingTimeRemaining {
+			t.Errorf("%s: expected working time remaining %v, but got %v", test.name, test.expectedWorkingTimeRemaining, result.WorkingTimeRemainingDuration)
+		}
+		if result.ElapsedDuration != test.expectedElapsed {
+			t.Errorf("%s: expected elapsed %v, but got %v", test.name, test.expectedElapsed, result.ElapsedDuration)
+		}
+		if result.PausedDuration != test.expectedPaused {
+			t.Errorf("%s: expected paused %v, but got %v", test.name, test.expectedPaused, result.PausedDuration)
+		}
+		if result.PercentConsumed != test.expectedPercentConsumed {
+			t.Errorf("%s: expected percent consumed %v, but got %v", test.name, test.expectedPercentConsumed, result.PercentConsumed)
+		}
+
+		// The strings describe the same durations
+		if result.Elapsed != formatDuration(test.expectedElapsed) {
+			t.Errorf("%s: expected elapsed %s, but got %s", test.name, formatDuration(test.expectedElapsed), result.Elapsed)
+		}
+	}
+}
+
+func TestSLAResultJSON(t *testing.T) {
+	result := SLAResult{
+		IsWithinSLA:                  true,
+		Status:                       StatusOnTrack,
+		Deadline:                     time.Date(2024, time.August, 27, 13, 30, 0, 0, time.UTC),
+		Remaining:                    "01:30:00",
+		Overage:                      "00:00:00",
+		WorkingTimeRemaining:         "01:30:00",
+		Elapsed:                      "02:30:00",
+		PausedTime:                   "00:30:00",
+		PercentConsumed:              62.5,
+		RemainingDuration:            90 * time.Minute,
+		WorkingTimeRemainingDuration: 90 * time.Minute,
+		ElapsedDuration:              150 * time.Minute,
+		PausedDuration:               30 * time.Minute,
+	}
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		t.Fatalf("Error marshaling JSON: %v", err)
+	}
+
+	var decoded map[string]interface{}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Error unmarshaling JSON: %v", err)
+	}
+
+	expected := map[string]interface{}{
+		"isWithinSLA":                 true,
+		"status":                      "on-track",
+		"deadline":                    "2024-08-27T13:30:00Z",
+		"remaining":                   "01:30:00",
+		"remainingSeconds":            5400.0,
+		"overage":                     "00:00:00",
+		"overageSeconds":              0.0,
+		"workingTimeRemaining":        "01:30:00",
+		"workingTimeRemainingSeconds": 5400.0,
+		"elapsed":                     "02:30:00",
+		"elapsedSeconds":              9000.0,
+		"pausedTime":                  "00:30:00",
+		"pausedTimeSeconds":           1800.0,
+		"percentConsumed":             62.5,
+	}
+	for key, value := range expected {
+		if decoded[key] != value {
+			t.Errorf("Expected %s to be %v, but got %v", key, value, decoded[key])
+		}
+	}
+	if len(decoded) != len(expected) {
+		t.Errorf("Expected %d JSON fields, but got %d: %s", len(expected), len(decoded), data)
+	}
+}
+
+func TestBusinessDurationBetween(t *testing.T) {
+	// Define holidays
+	holidays := []time.Time{
+		time.Date(2024, time.August, 26, 0, 0, 0, 0, time.UTC), // Holiday on Monday August 26, 2024
+	}
+
+	// Define common SLA configuration for the tests with holidays
+	sla := setupSLAWithHolidays(holidays)
+
+	tests := []struct {
+		name     string
+		from     time.Time
+		to       time.Time
+		expected time.Duration
+	}{
+		{
+			name:     "within one day",
+			from:     time.Date(2024, time.August, 27, 10, 15, 0, 0, time.UTC), // Tuesday 10:15 AM
+			to:       time.Date(2024, time.August, 27, 15, 45, 0, 0, time.UTC),
+			expected: 5*time.Hour + 30*time.Minute,
+		},
+		{
+			name:     "mid-window to next morning",
+			from:     time.Date(2024, time.August, 27, 16, 30, 0, 0, time.UTC), // Tuesday 4:30 PM
+			to:       time.Date(2024, time.August, 28, 9, 40, 0, 0, time.UTC),  // Wednesday 9:40 AM
+			expected: 70 * time.Minute,
+		},
+		{
+			name:     "both outside business hours",
+			from:     time.Date(2024, time.August, 27, 18, 0, 0, 0, time.UTC), // Tuesday 6 PM
+			to:       time.Date(2024, time.August, 28, 8, 0, 0, 0, time.UTC),  // Wednesday 8 AM
+			expected: 0,
+		},
+		{
+			name:     "from the weekend",
+			from:     time.Date(2024, time.August, 31, 12, 0, 0, 0, time.UTC), // Saturday 12 PM
+			to:       time.Date(2024, time.September, 2, 10, 0, 0, 0, time.UTC),
+			expected: time.Hour,
+		},
+		{
+			name:     "over a holiday weekend",
+			from:     time.Date(2024, time.August, 23, 16, 0, 0, 0, time.UTC), // Friday 4 PM
+			to:       time.Date(2024, time.August, 27, 10, 0, 0, 0, time.UTC), // Tuesday 10 AM
+			expected: 2 * time.Hour,
+		},
+		{
+			name:     "reversed",
+			from:     time.Date(2024, time.August, 27, 15, 45, 0, 0, time.UTC),
+			to:       time.Date(2024, time.August, 27, 10, 15, 0, 0, time.UTC),
+			expected: -(5*time.Hour + 30*time.Minute),
+		},
+		{
+			name:     "sub-second precision",
+			from:     time.Date(2024, time.August, 27, 16, 59, 59, 0, time.UTC),
+			to:       time.Date(2024, time.August, 28, 9, 0, 0, 500000000, time.UTC),
+			expected: 1500 * time.Millisecond,
+		},
+		{
+			name:     "same instant",
+			from:     time.Date(2024, time.August, 27, 11, 0, 0, 0, time.UTC),
+			to:       time.Date(2024, time.August, 27, 11, 0, 0, 0, time.UTC),
+			expected: 0,
+		},
+	}
+
+	for _, test := range tests {
+		duration, err := sla.BusinessDurationBetween(test.from, test.to)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", test.name, err)
+		}
+		if duration != test.expected {
+			t.Errorf("%s: expected %v, but got %v", test.name, test.expected, duration)
+		}
+	}
+
+	// The SLA length is not needed, but the calendar must be valid
+	sla.SLALength = 0
+	if _, err := sla.BusinessDurationBetween(tests[0].from, tests[0].to); err != nil {
+		t.Errorf("Unexpected error without an SLA length: %v", err)
+	}
+	sla.ValidDays = nil
+	if _, err := sla.BusinessDurationBetween(tests[0].from, tests[0].to); !errors.Is(err, ErrNoValidDays) {
+		t.Errorf("Expected error %v, but got %v", ErrNoValidDays, err)
+	}
+}
+
+func TestLatestStartTime(t *testing.T) {
+	// Define holidays
+	holidays := []time.Time{
+		time.Date(2024, time.August, 26, 0, 0, 0, 0, time.UTC), // Holiday on Monday August 26, 2024
+	}
+
+	// Define common SLA configuration for the tests with holidays
+	sla := setupSLAWithHolidays(holidays)
+
+	tests := []struct {
+		name          string
+		deadline      time.Time
+		slaLength     int
+		timeUnit      string
+		expectedStart time.Time
+	}{
+		{
+			name:          "previous afternoon",
+			deadline:      time.Date(2024, time.August, 29, 12, 0, 0, 0, time.UTC), // Thursday 12 PM
+			slaLength:     6,
+			timeUnit:      "hours",
+			expectedStart: time.Date(2024, time.August, 28, 14, 0, 0, 0, time.UTC), // Wednesday 2 PM
+		},
+		{
+			name:          "same morning",
+			deadline:      time.Date(2024, time.August, 29, 12, 0, 0, 0, time.UTC), // Thursday 12 PM
+			slaLength:     90,
+			timeUnit:      "minutes",
+			expectedStart: time.Date(2024, time.August, 29, 10, 30, 0, 0, time.UTC),
+		},
+		{
+			name:          "lands on opening time",
+			deadline:      time.Date(2024, time.August, 29, 12, 0, 0, 0, time.UTC), // Thursday 12 PM
+			slaLength:     3,
+			timeUnit:      "hours",
+			expectedStart: time.Date(2024, time.August, 29, 9, 0, 0, 0, time.UTC),
+		},
+		{
+			name:          "deadline outside business hours",
+			deadline:      time.Date(2024, time.August, 29, 20, 0, 0, 0, time.UTC), // Thursday 8 PM
+			slaLength:     2,
+			timeUnit:      "hours",
+			expectedStart: time.Date(2024, time.August, 29, 15, 0, 0, 0, time.UTC),
+		},
+		{
+			name:          "over a holiday weekend",
+			deadline:      time.Date(2024, time.August, 27, 11, 0, 0, 0, time.UTC), // Tuesday 11 AM
+			slaLength:     4,
+			timeUnit:      "hours",
+			expectedStart: time.Date(2024, time.August, 23, 15, 0, 0, 0, time.UTC), // Friday 3 PM
+		},
+		{
+			name:          "business days",
+			deadline:      time.Date(2024, time.August, 29, 14, 0, 0, 0, time.UTC), // Thursday 2 PM
+			slaLength:     2,
+			timeUnit:      TimeUnitBusinessDays,
+			expectedStart: time.Date(2024, time.August, 27, 14, 0, 0, 0, time.UTC), // Tuesday 2 PM
+		},
+		{
+			name:          "business days over a holiday weekend",
+			deadline:      time.Date(2024, time.August, 27, 11, 0, 0, 0, time.UTC), // Tuesday 11 AM
+			slaLength:     1,
+			timeUnit:      TimeUnitBusinessDays,
+			expectedStart: time.Date(2024, time.August, 23, 11, 0, 0, 0, time.UTC), // Friday 11 AM
+		},
+		{
+			name:          "business days rolled back to closing time",
+			deadline:      time.Date(2024, time.August, 29, 20, 0, 0, 0, time.UTC), // Thursday 8 PM
+			slaLength:     2,
+			timeUnit:      TimeUnitBusinessDays,
+			expectedStart: time.Date(2024, time.August, 27, 17, 0, 0, 0, time.UTC), // Tuesday 5 PM
+		},
+	}
+
+	for _, test := range tests {
+		// Set up SLA with the test parameters
+		sla.SLALength = test.slaLength
+		sla.TimeUnit = test.timeUnit
+
+		start, err := sla.LatestStartTime(test.deadline)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", test.name, err)
+		}
+		if !start.Equal(test.expectedStart) {
+			t.Errorf("%s: expected latest start %v, but got %v", test.name, test.expectedStart, start)
+		}
+
+		// Starting at the latest start meets the deadline exactly when the deadline is in business hours
+		sla.StartTime = start
+		deadline, err := sla.Deadline(start)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", test.name, err)
+		}
+		if sla.calendar().isOpen(test.deadline) && !deadline.Equal(test.deadline) {
+			t.Errorf("%s: expected starting at %v to give deadline %v, but got %v", test.name, start, test.deadline, deadline)
+		}
+	}
+}
+
+func TestSubtractBusinessTime(t *testing.T) {
+	// Define the SLA with the clock stopped over lunch
+	lunch := setupSLAWithHolidays(nil)
+	lunch.BusinessWindows = []BusinessHours{
+		{Start: NewTimeOfDay(9, 0), End: NewTimeOfDay(12, 0)},
+		{Start: NewTimeOfDay(13, 0), End: NewTimeOfDay(17, 30)},
+	}
+
+	// Define the SLA for a night operations team working 22:00-06:00
+	night := setupSLAWithHolidays(nil)
+	night.BusinessHours = BusinessHours{Start: NewTimeOfDay(22, 0), End: NewTimeOfDay(6, 0)}
+
+	tests := []struct {
+		name     string
+		sla      SLA
+		t        time.Time
+		duration time.Duration
+		expected time.Time
+	}{
+		{
+			name:     "over lunch",
+			sla:      lunch,
+			t:        time.Date(2024, time.August, 27, 14, 0, 0, 0, time.UTC), // Tuesday 2 PM
+			duration: 2 * time.Hour,
+			expected: time.Date(2024, time.August, 27, 11, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "from lunch",
+			sla:      lunch,
+			t:        time.Date(2024, time.August, 27, 12, 30, 0, 0, time.UTC), // Tuesday 12:30 PM
+			duration: 4 * time.Hour,
+			expected: time.Date(2024, time.August, 26, 16, 30, 0, 0, time.UTC),
+		},
+		{
+			name:     "overnight shift",
+			sla:      night,
+			t:        time.Date(2024, time.August, 28, 2, 0, 0, 0, time.UTC), // Wednesday 2 AM, Tuesday's shift
+			duration: 6 * time.Hour,
+			expected: time.Date(2024, time.August, 27, 4, 0, 0, 0, time.UTC), // Monday's shift
+		},
+		{
+			name:     "nothing to subtract",
+			sla:      night,
+			t:        time.Date(2024, time.August, 28, 12, 0, 0, 0, time.UTC),
+			duration: 0,
+			expected: time.Date(2024, time.August, 28, 12, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, test := range tests {
+		result, err := test.sla.SubtractBusinessTime(test.t, test.duration)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", test.name, err)
+		}
+		if !result.Equal(test.expected) {
+			t.Errorf("%s: expected %v, but got %v", test.name, test.expected, result)
+		}
+	}
+
+	if _, err := lunch.SubtractBusinessTime(tests[0].t, -time.Hour); !errors.Is(err, ErrNegativeDuration) {
+		t.Errorf("Expected error %v, but got %v", ErrNegativeDuration, err)
+	}
+}
+
+func TestCheckSLAWithBusinessDays(t *testing.T) {
+	tests := []struct {
+		name             string
+		startTime        time.Time
+		slaLength        int
+		windows          []BusinessHours
+		holidays         []time.Time
+		pauses           []Pause
+		expectedDeadline time.Time
+	}{
+		{
+			name:             "same time two days later",
+			startTime:        time.Date(2024, time.September, 3, 14, 0, 0, 0, time.UTC), // Tuesday 2 PM
+			slaLength:        2,
+			expectedDeadline: time.Date(2024, time.September, 5, 14, 0, 0, 0, time.UTC), // Thursday 2 PM
+		},
+		{
+			name:             "skips a holiday",
+			startTime:        time.Date(2024, time.September, 3, 14, 0, 0, 0, time.UTC), // Tuesday 2 PM
+			slaLength:        2,
+			holidays:         []time.Time{time.Date(2024, time.September, 4, 0, 0, 0, 0, time.UTC)},
+			expectedDeadline: time.Date(2024, time.September, 6, 14, 0, 0, 0, time.UTC), // Friday 2 PM
+		},
+		{
+			name:             "skips the weekend",
+			startTime:        time.Date(2024, time.September, 6, 14, 0, 0, 0, time.UTC), // Friday 2 PM
+			slaLength:        1,
+			expectedDeadline: time.Date(2024, time.September, 9, 14, 0, 0, 0, time.UTC), // Monday 2 PM
+		},
+		{
+			name:             "closed at that time rolls to the next opening",
+			startTime:        time.Date(2024, time.September, 3, 20, 0, 0, 0, time.UTC), // Tuesday 8 PM
+			slaLength:        2,
+			expectedDeadline: time.Date(2024, time.September, 6, 9, 0, 0, 0, time.UTC), // Friday 9 AM
+		},
+		{
+			name:             "starting at the weekend counts from Monday's opening",
+			startTime:        time.Date(2024, time.September, 7, 10, 0, 0, 0, time.UTC), // Saturday 10 AM
+			slaLength:        2,
+			expectedDeadline: time.Date(2024, time.September, 11, 9, 0, 0, 0, time.UTC), // Wednesday 9 AM
+		},
+		{
+			name:             "starting before opening at the weekend",
+			startTime:        time.Date(2024, time.September, 7, 8, 0, 0, 0, time.UTC), // Saturday 8 AM
+			slaLength:        1,
+			expectedDeadline: time.Date(2024, time.September, 10, 9, 0, 0, 0, time.UTC), // Tuesday 9 AM
+		},
+		{
+			name:             "starting on a holiday",
+			startTime:        time.Date(2024, time.September, 2, 14, 0, 0, 0, time.UTC), // Monday 2 PM, a holiday
+			slaLength:        1,
+			holidays:         []time.Time{time.Date(2024, time.September, 2, 0, 0, 0, 0, time.UTC)},
+			expectedDeadline: time.Date(2024, time.September, 4, 9, 0, 0, 0, time.UTC), // Wednesday 9 AM
+		},
+		{
+			name:      "during a lunch break",
+			startTime: time.Date(2024, time.September, 3, 12, 30, 0, 0, time.UTC), // Tuesday 12:30 PM
+			slaLength: 2,
+			windows: []BusinessHours{
+				{Start: NewTimeOfDay(9, 0), End: NewTimeOfDay(12, 0)},
+				{Start: NewTimeOfDay(13, 0), End: NewTimeOfDay(17, 0)},
+			},
+			expectedDeadline: time.Date(2024, time.September, 5, 13, 0, 0, 0, time.UTC), // Thursday 1 PM
+		},
+		{
+			name:      "extended by pauses",
+			startTime: time.Date(2024, time.September, 3, 14, 0, 0, 0, time.UTC), // Tuesday 2 PM
+			slaLength: 2,
+			pauses: []Pause{
+				{Start: time.Date(2024, time.September, 3, 15, 0, 0, 0, time.UTC), End: time.Date(2024, time.September, 4, 9, 0, 0, 0, time.UTC)},
+			},
+			expectedDeadline: time.Date(2024, time.September, 5, 16, 0, 0, 0, time.UTC), // Thursday 4 PM
+		},
+	}
+
+	for _, test := range tests {
+		sla := setupSLAWithHolidays(test.holidays)
+		sla.StartTime = test.startTime
+		sla.SLALength = test.slaLength
+		sla.TimeUnit = TimeUnitBusinessDays
+		sla.BusinessWindows = test.windows
+		sla.Pauses = test.pauses
+
+		deadline, err := sla.Deadline(test.startTime)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", test.name, err)
+		}
+		if !deadline.Equal(test.expectedDeadline) {
+			t.Errorf("%s: expected deadline %v, but got %v", test.name, test.expectedDeadline, deadline)
+		}
+
+		// Every start gets a full 8 hours of business time per business day
+		if test.windows == nil && test.pauses == nil {
+			expected := time.Duration(test.slaLength) * 8 * time.Hour
+			if businessTime, _ := sla.BusinessDurationBetween(test.startTime, deadline); businessTime != expected {
+				t.Errorf("%s: expected %v of business time before the deadline, but got %v", test.name, expected, businessTime)
+			}
+		}
+	}
+}
+
+func TestLatestStartTimeWithBusinessDays(t *testing.T) {
+	tests := []struct {
+		name          string
+		deadline      time.Time
+		holidays      []time.Time
+		expectedStart time.Time
+	}{
+		{
+			name:          "deadline at the weekend",
+			deadline:      time.Date(2024, time.September, 8, 12, 0, 0, 0, time.UTC), // Sunday noon
+			expectedStart: time.Date(2024, time.September, 5, 17, 0, 0, 0, time.UTC), // Thursday 5 PM
+		},
+		{
+			name:          "deadline on a holiday",
+			deadline:      time.Date(2024, time.September, 2, 14, 0, 0, 0, time.UTC), // Monday 2 PM, a holiday
+			holidays:      []time.Time{time.Date(2024, time.September, 2, 0, 0, 0, 0, time.UTC)},
+			expectedStart: time.Date(2024, time.August, 29, 17, 0, 0, 0, time.UTC), // Thursday 5 PM
+		},
+		{
+			name:          "deadline during business hours",
+			deadline:      time.Date(2024, time.September, 9, 12, 0, 0, 0, time.UTC), // Monday noon
+			expectedStart: time.Date(2024, time.September, 6, 12, 0, 0, 0, time.UTC), // Friday noon
+		},
+	}
+
+	for _, test := range tests {
+		sla := setupSLAWithHolidays(test.holidays)
+		sla.SLALength = 1
+		sla.TimeUnit = TimeUnitBusinessDays
+
+		start, err := sla.LatestStartTime(test.deadline)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", test.name, err)
+		}
+		if !start.Equal(test.expectedStart) {
+			t.Errorf("%s: expected latest start %v, but got %v", test.name, test.expectedStart, start)
+		}
+		if businessTime, _ := sla.BusinessDurationBetween(start, test.deadline); businessTime != 8*time.Hour {
+			t.Errorf("%s: expected 8h0m0s of business time before the deadline, but got %v", test.name, businessTime)
+		}
+	}
+}
+
+func TestCheckSLAWithClockMode(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatalf("Failed to load location: %v", err)
+	}
+
+	holidays := []time.Time{
+		time.Date(2024, time.September, 2, 0, 0, 0, 0, time.UTC), // Holiday on Monday September 2, 2024
+	}
+
+	tests := []struct {
+		name             string
+		clockMode        ClockMode
+		startTime        time.Time
+		slaLength        int
+		location         *time.Location
+		currentTime      time.Time
+		expectedDeadline time.Time
+		expectedWithin   bool
+	}{
+		{
+			name:             "business hours",
+			clockMode:        ClockModeBusiness,
+			startTime:        time.Date(2024, time.August, 30, 15, 0, 0, 0, time.UTC), // Friday 3 PM
+			slaLength:        4,
+			currentTime:      time.Date(2024, time.August, 31, 12, 0, 0, 0, time.UTC),
+			expectedDeadline: time.Date(2024, time.September, 3, 11, 0, 0, 0, time.UTC), // Tuesday 11 AM
+			expectedWithin:   true,
+		},
+		{
+			name:             "calendar time",
+			clockMode:        ClockModeCalendar,
+			startTime:        time.Date(2024, time.August, 30, 15, 0, 0, 0, time.UTC), // Friday 3 PM
+			slaLength:        4,
+			currentTime:      time.Date(2024, time.August, 31, 12, 0, 0, 0, time.UTC),
+			expectedDeadline: time.Date(2024, time.August, 30, 19, 0, 0, 0, time.UTC), // Friday 7 PM
+			expectedWithin:   false,
+		},
+		{
+			name:             "calendar time runs through holidays",
+			clockMode:        ClockModeCalendar,
+			startTime:        time.Date(2024, time.August, 30, 15, 0, 0, 0, time.UTC), // Friday 3 PM
+			slaLength:        72,
+			currentTime:      time.Date(2024, time.September, 2, 12, 0, 0, 0, time.UTC),
+			expectedDeadline: time.Date(2024, time.September, 2, 15, 0, 0, 0, time.UTC), // Monday 3 PM
+			expectedWithin:   true,
+		},
+		{
+			name:             "calendar time skipping holidays",
+			clockMode:        ClockModeCalendarSkipHolidays,
+			startTime:        time.Date(2024, time.August, 30, 15, 0, 0, 0, time.UTC), // Friday 3 PM
+			slaLength:        72,
+			currentTime:      time.Date(2024, time.September, 2, 18, 0, 0, 0, time.UTC),
+			expectedDeadline: time.Date(2024, time.September, 3, 15, 0, 0, 0, time.UTC), // Tuesday 3 PM
+			expectedWithin:   true,
+		},
+		{
+			name:             "calendar time across daylight saving",
+			clockMode:        ClockModeCalendar,
+			startTime:        time.Date(2024, time.March, 9, 12, 0, 0, 0, newYork), // Saturday noon EST
+			slaLength:        24,
+			location:         newYork,
+			currentTime:      time.Date(2024, time.March, 10, 12, 30, 0, 0, newYork),
+			expectedDeadline: time.Date(2024, time.March, 10, 13, 0, 0, 0, newYork), // Sunday 1 PM EDT
+			expectedWithin:   true,
+		},
+	}
+
+	for _, test := range tests {
+		sla := setupSLAWithHolidays(holidays)
+		sla.ClockMode = test.clockMode
+		sla.StartTime = test.startTime
+		sla.SLALength = test.slaLength
+		sla.Location = test.location
+		if test.clockMode.isCalendar() {
+			// Business hours and valid days are not needed on calendar time
+			sla.BusinessHours = BusinessHours{}
+			sla.ValidDays = nil
+		}
+
+		result := sla.CheckSLA(test.currentTime)
+		if !result.Deadline.Equal(test.expectedDeadline) {
+			t.Errorf("%s: expected deadline %v, but got %v", test.name, test.expectedDeadline, result.Deadline)
+		}
+		if result.IsWithinSLA != test.expectedWithin {
+			t.Errorf("%s: expected IsWithinSLA to be %v, but got %v", test.name, test.expectedWithin, result.IsWithinSLA)
+		}
+	}
+}
+
+func TestCheckSLAWithLength(t *testing.T) {
+	startTime := time.Date(2024, time.September, 3, 14, 0, 0, 0, time.UTC) // Tuesday 2 PM
+
+	tests := []struct {
+		length           string
+		expectedDeadline time.Time
+	}{
+		{length: "PT1H30M", expectedDeadline: time.Date(2024, time.September, 3, 15, 30, 0, 0, time.UTC)},
+		{length: "1h30m", expectedDeadline: time.Date(2024, time.September, 3, 15, 30, 0, 0, time.UTC)},
+		{length: "PT4H", expectedDeadline: time.Date(2024, time.September, 4, 10, 0, 0, 0, time.UTC)},
+		{length: "P2D", expectedDeadline: time.Date(2024, time.September, 5, 14, 0, 0, 0, time.UTC)},
+		{length: "P1W", expectedDeadline: time.Date(2024, time.September, 10, 14, 0, 0, 0, time.UTC)},
+		{length: "P1DT4H", expectedDeadline: time.Date(2024, time.September, 5, 10, 0, 0, 0, time.UTC)},
+	}
+
+	for _, test := range tests {
+		sla := setupSLAWithHolidays(nil)
+		sla.StartTime = startTime
+		sla.SLALength = 0 // Ignored when Length is set
+		sla.TimeUnit = ""
+		sla.Length = test.length
+
+		deadline, err := sla.Deadline(startTime)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", test.length, err)
+		}
+		if !deadline.Equal(test.expectedDeadline) {
+			t.Errorf("%s: expected deadline %v, but got %v", test.length, test.expectedDeadline, deadline)
+		}
+
+		// Working backwards from the deadline gives the start time again
+		start, err := sla.LatestStartTime(deadline)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", test.length, err)
+		}
+		if !start.Equal(startTime) {
+			t.Errorf("%s: expected latest start %v, but got %v", test.length, startTime, start)
+		}
+	}
+}
+
+func TestCheckSLAStatus(t *testing.T) {
+	startTime := time.Date(2024, time.August, 27, 9, 0, 0, 0, time.UTC) // Tuesday 9 AM, due at 1 PM
+
+	tests := []struct {
+		name           string
+		currentTime    time.Time
+		completedAt    time.Time
+		pauses         []Pause
+		atRisk         AtRiskThreshold
+		expectedStatus Status
+		expectedWithin bool
+	}{
+		{
+			name:           "on track",
+			currentTime:    time.Date(2024, time.August, 27, 11, 0, 0, 0, time.UTC),
+			atRisk:         AtRiskThreshold{PercentConsumed: 75},
+			expectedStatus: StatusOnTrack,
+			expectedWithin: true,
+		},
+		{
+			name:           "at risk by percent consumed",
+			currentTime:    time.Date(2024, time.August, 27, 12, 0, 0, 0, time.UTC),
+			atRisk:         AtRiskThreshold{PercentConsumed: 75},
+			expectedStatus: StatusAtRisk,
+			expectedWithin: true,
+		},
+		{
+			name:           "at risk by time remaining",
+			currentTime:    time.Date(2024, time.August, 27, 12, 30, 0, 0, time.UTC),
+			atRisk:         AtRiskThreshold{Remaining: 30 * time.Minute},
+			expectedStatus: StatusAtRisk,
+			expectedWithin: true,
+		},
+		{
+			name:           "no threshold",
+			currentTime:    time.Date(2024, time.August, 27, 12, 59, 0, 0, time.UTC),
+			expectedStatus: StatusOnTrack,
+			expectedWithin: true,
+		},
+		{
+			name:           "breached",
+			currentTime:    time.Date(2024, time.August, 27, 14, 0, 0, 0, time.UTC),
+			atRisk:         AtRiskThreshold{PercentConsumed: 75},
+			expectedStatus: StatusBreached,
+			expectedWithin: false,
+		},
+		{
+			name:           "paused",
+			currentTime:    time.Date(2024, time.August, 27, 14, 0, 0, 0, time.UTC),
+			pauses:         []Pause{{Start: time.Date(2024, time.August, 27, 12, 0, 0, 0, time.UTC)}},
+			atRisk:         AtRiskThreshold{PercentConsumed: 75},
+			expectedStatus: StatusPaused,
+			expectedWithin: true,
+		},
+		{
+			name:           "met",
+			currentTime:    time.Date(2024, time.August, 28, 10, 0, 0, 0, time.UTC),
+			completedAt:    time.Date(2024, time.August, 27, 12, 0, 0, 0, time.UTC),
+			expectedStatus: StatusMet,
+			expectedWithin: true,
+		},
+		{
+			name:           "completed late",
+			currentTime:    time.Date(2024, time.August, 28, 10, 0, 0, 0, time.UTC),
+			completedAt:    time.Date(2024, time.August, 27, 15, 0, 0, 0, time.UTC),
+			expectedStatus: StatusBreached,
+			expectedWithin: false,
+		},
+		{
+			name:           "completed in the future",
+			currentTime:    time.Date(2024, time.August, 27, 11, 0, 0, 0, time.UTC),
+			completedAt:    time.Date(2024, time.August, 27, 12, 0, 0, 0, time.UTC),
+			expectedStatus: StatusOnTrack,
+			expectedWithin: true,
+		},
+	}
+
+	for _, test := range tests {
+		sla := setupSLAWithHolidays(nil)
+		sla.StartTime = startTime
+		sla.CompletedAt = test.completedAt
+		sla.Pauses = test.pauses
+		sla.AtRisk = test.atRisk
+
+		result := sla.CheckSLA(test.currentTime)
+		if result.Status != test.expectedStatus {
+			t.Errorf("%s: expected status %q, but got %q", test.name, test.expectedStatus, result.Status)
+		}
+		if result.IsWithinSLA != test.expectedWithin {
+			t.Errorf("%s: expected IsWithinSLA to be %v, but got %v", test.name, test.expectedWithin, result.IsWithinSLA)
+		}
+		if within := sla.IsWithinSLA(test.currentTime); within != test.expectedWithin {
+			t.Errorf("%s: expected IsWithinSLA() to be %v, but got %v", test.name, test.expectedWithin, within)
+		}
+	}
+}
+
+func TestCheckSLAWithReducedHours(t *testing.T) {
+	holidays := []time.Time{
+		time.Date(2024, time.December, 25, 0, 0, 0, 0, time.UTC), // Christmas Day, Wednesday
+		time.Date(2024, time.December, 31, 0, 0, 0, 0, time.UTC), // New Year's Eve, Tuesday, opening for the morning
+	}
+	reducedHours := []DayHours{
+		{
+			Date:  time.Date(2024, time.December, 24, 0, 0, 0, 0, time.UTC), // Christmas Eve, closing at 1 PM
+			Hours: []BusinessHours{{Start: NewTimeOfDay(9, 0), End: NewTimeOfDay(13, 0)}},
+		},
+		{
+			Date:  time.Date(2024, time.December, 28, 0, 0, 0, 0, time.UTC), // Saturday, opening for the morning
+			Hours: []BusinessHours{{Start: NewTimeOfDay(10, 0), End: NewTimeOfDay(12, 0)}},
+		},
+		{
+			Date:  time.Date(2024, time.December, 31, 0, 0, 0, 0, time.UTC), // New Year's Eve, also a holiday
+			Hours: []BusinessHours{{Start: NewTimeOfDay(9, 0), End: NewTimeOfDay(12, 0)}},
+		},
+	}
+
+	tests := []struct {
+		name             string
+		startTime        time.Time
+		slaLength        int
+		ignoreHolidays   bool
+		clockMode        ClockMode
+		expectedDeadline time.Time
+	}{
+		{
+			name:             "closes early",
+			startTime:        time.Date(2024, time.December, 24, 11, 0, 0, 0, time.UTC),
+			slaLength:        4,
+			expectedDeadline: time.Date(2024, time.December, 26, 11, 0, 0, 0, time.UTC),
+		},
+		{
+			name:             "starts after the early close",
+			startTime:        time.Date(2024, time.December, 24, 14, 0, 0, 0, time.UTC),
+			slaLength:        1,
+			expectedDeadline: time.Date(2024, time.December, 26, 10, 0, 0, 0, time.UTC),
+		},
+		{
+			name:             "opens on a day that is usually closed",
+			startTime:        time.Date(2024, time.December, 27, 16, 0, 0, 0, time.UTC),
+			slaLength:        2,
+			expectedDeadline: time.Date(2024, time.December, 28, 11, 0, 0, 0, time.UTC),
+		},
+		{
+			name:             "ignored along with holidays",
+			startTime:        time.Date(2024, time.December, 24, 11, 0, 0, 0, time.UTC),
+			slaLength:        4,
+			ignoreHolidays:   true,
+			expectedDeadline: time.Date(2024, time.December, 24, 15, 0, 0, 0, time.UTC),
+		},
+		{
+			name:             "a holiday opens for its reduced hours",
+			startTime:        time.Date(2024, time.December, 30, 16, 0, 0, 0, time.UTC),
+			slaLength:        3,
+			expectedDeadline: time.Date(2024, time.December, 31, 11, 0, 0, 0, time.UTC),
+		},
+		{
+			name:             "ignored when the clock runs on calendar time",
+			startTime:        time.Date(2024, time.December, 24, 8, 0, 0, 0, time.UTC),
+			slaLength:        8,
+			clockMode:        ClockModeCalendarSkipHolidays,
+			expectedDeadline: time.Date(2024, time.December, 24, 16, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, test := range tests {
+		sla := setupSLAWithHolidays(holidays)
+		sla.ReducedHours = reducedHours
+		sla.StartTime = test.startTime
+		sla.SLALength = test.slaLength
+		sla.IgnoreHolidays = test.ignoreHolidays
+		sla.ClockMode = test.clockMode
+
+		result, err := sla.Evaluate(test.startTime)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", test.name, err)
+		}
+		if !result.Deadline.Equal(test.expectedDeadline) {
+			t.Errorf("%s: expected deadline %v, but got %v", test.name, test.expectedDeadline, result.Deadline)
+		}
+		if result.WorkingTimeRemainingDuration != time.Duration(test.slaLength)*time.Hour {
+			t.Errorf("%s: expected %v of working time remaining, but got %v", test.name, time.Duration(test.slaLength)*time.Hour, result.WorkingTimeRemainingDuration)
+		}
+	}
+}
+
+func TestCheckSLAWithClosuresAndExtraHours(t *testing.T) {
+	holidays := []time.Time{
+		time.Date(2024, time.September, 2, 0, 0, 0, 0, time.UTC), // Holiday on Monday September 2, 2024
+	}
+	saturdayMorning := DayHours{
+		Date:  time.Date(2024, time.September, 7, 0, 0, 0, 0, time.UTC),
+		Hours: []BusinessHours{{Start: NewTimeOfDay(10, 0), End: NewTimeOfDay(14, 0)}},
+	}
+
+	tests := []struct {
+		name             string
+		startTime        time.Time
+		slaLength        int
+		extraHours       []DayHours
+		closures         []Closure
+		expectedDeadline time.Time
+	}{
+		{
+			name:      "closed across two days",
+			startTime: time.Date(2024, time.September, 3, 10, 0, 0, 0, time.UTC), // Tuesday 10 AM
+			slaLength: 4,
+			closures: []Closure{
+				{Start: time.Date(2024, time.September, 3, 12, 0, 0, 0, time.UTC), End: time.Date(2024, time.September, 4, 12, 0, 0, 0, time.UTC)},
+			},
+			expectedDeadline: time.Date(2024, time.September, 4, 14, 0, 0, 0, time.UTC),
+		},
+		{
+			name:             "make-up Saturday",
+			startTime:        time.Date(2024, time.September, 6, 15, 0, 0, 0, time.UTC), // Friday 3 PM
+			slaLength:        4,
+			extraHours:       []DayHours{saturdayMorning},
+			expectedDeadline: time.Date(2024, time.September, 7, 12, 0, 0, 0, time.UTC),
+		},
+		{
+			name:      "extra hours overlapping the usual ones",
+			startTime: time.Date(2024, time.September, 3, 16, 0, 0, 0, time.UTC), // Tuesday 4 PM
+			slaLength: 3,
+			extraHours: []DayHours{{
+				Date:  time.Date(2024, time.September, 3, 0, 0, 0, 0, time.UTC),
+				Hours: []BusinessHours{{Start: NewTimeOfDay(16, 0), End: NewTimeOfDay(19, 0)}},
+			}},
+			expectedDeadline: time.Date(2024, time.September, 3, 19, 0, 0, 0, time.UTC),
+		},
+		{
+			name:      "extra hours on a holiday",
+			startTime: time.Date(2024, time.August, 30, 16, 0, 0, 0, time.UTC), // Friday 4 PM
+			slaLength: 2,
+			extraHours: []DayHours{{
+				Date:  time.Date(2024, time.September, 2, 0, 0, 0, 0, time.UTC),
+				Hours: []BusinessHours{{Start: NewTimeOfDay(9, 0), End: NewTimeOfDay(12, 0)}},
+			}},
+			expectedDeadline: time.Date(2024, time.September, 2, 10, 0, 0, 0, time.UTC),
+		},
+		{
+			name:       "closure during extra hours",
+			startTime:  time.Date(2024, time.September, 6, 15, 0, 0, 0, time.UTC), // Friday 3 PM
+			slaLength:  4,
+			extraHours: []DayHours{saturdayMorning},
+			closures: []Closure{
+				{Start: time.Date(2024, time.September, 7, 10, 0, 0, 0, time.UTC), End: time.Date(2024, time.September, 7, 11, 0, 0, 0, time.UTC)},
+			},
+			expectedDeadline: time.Date(2024, time.September, 7, 13, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, test := range tests {
+		sla := setupSLAWithHolidays(holidays)
+		sla.StartTime = test.startTime
+		sla.SLALength = test.slaLength
+		sla.ExtraHours = test.extraHours
+		sla.Closures = test.closures
+
+		result, err := sla.Evaluate(test.startTime)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", test.name, err)
+		}
+		if !result.Deadline.Equal(test.expectedDeadline) {
+			t.Errorf("%s: expected deadline %v, but got %v", test.name, test.expectedDeadline, result.Deadline)
+		}
+		if result.WorkingTimeRemainingDuration != time.Duration(test.slaLength)*time.Hour {
+			t.Errorf("%s: expected %v of working time remaining, but got %v", test.name, time.Duration(test.slaLength)*time.Hour, result.WorkingTimeRemainingDuration)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package slachecker
+
+import (
+	"fmt"
+	"time"
+)
+
+// Status is the health of an SLA at the time it is evaluated
+type Status string
+
+// Statuses reported in SLAResult.Status
+const (
+	StatusOnTrack  Status = "on-track" // Running with time to spare
+	StatusAtRisk   Status = "at-risk"  // Running and past the AtRisk threshold, but not yet breached
+	StatusBreached Status = "breached" // The deadline has passed, or the work was completed after it
+	StatusPaused   Status = "paused"   // The clock is stopped by an open pause
+	StatusMet      Status = "met"      // The work was completed before the deadline
+)
+
+// severity orders the statuses from met, the least severe, to breached, the most severe
+func (s Status) severity() int {
+	switch s {
+	case StatusMet:
+		return 0
+	case StatusOnTrack:
+		return 1
+	case StatusPaused:
+		return 2
+	case StatusAtRisk:
+		return 3
+	case StatusBreached:
+		return 4
+	default:
+		return -1
+	}
+}
+
+// AtRiskThreshold decides when a running SLA turns at risk before it is breached.
+// Either or both may be set, and the SLA is at risk as soon as one of them is reached.
+type AtRiskThreshold struct {
+	PercentConsumed float64       // Percentage of the SLA's business time used, e.g. 75; zero disables it
+	Remaining       time.Duration // Business time remaining until the deadline, e.g. 30 * time.Minute; zero disables it
+}
+
+// validate checks the percentage lies between 0 and 100 and the remaining time is not negative
+func (a AtRiskThreshold) validate() error {
+	if a.PercentConsumed < 0 || a.PercentConsumed > 100 {
+		return &ValidationError{Field: "PercentConsumed", Err: fmt.Errorf("%w: %v must be between 0 and 100", ErrInvalidThreshold, a.PercentConsumed)}
+	}
+	if a.Remaining < 0 {
+		return &ValidationError{Field: "Remaining", Err: fmt.Errorf("%w: %v cannot be negative", ErrInvalidThreshold, a.Remaining)}
+	}
+	return nil
+}
+
+// reached reports whether either threshold has been reached
+func (a AtRiskThreshold) reached(percentConsumed float64, workingTimeRemaining time.Duration) bool {
+	if a.PercentConsumed > 0 && percentConsumed >= a.PercentConsumed {
+		return true
+	}
+	return a.Remaining > 0 && workingTimeRemaining <= a.Remaining
+}
@@ -0,0 +1,131 @@
+package slachecker
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TimeOfDay is a wall-clock time within a day with minute precision, e.g. 08:30.
+// 24:00 is allowed as a closing time and means midnight at the end of the day.
+type TimeOfDay struct {
+	Hour   int
+	Minute int
+}
+
+// BusinessHours is the daily window during which the SLA clock runs.
+// A window whose End is before its Start runs overnight, e.g. 22:00-06:00.
+type BusinessHours struct {
+	Start TimeOfDay // Opening time, e.g. 08:30
+	End   TimeOfDay // Closing time, e.g. 17:45
+}
+
+// NewTimeOfDay creates a TimeOfDay from an hour and a minute
+func NewTimeOfDay(hour, minute int) TimeOfDay {
+	return TimeOfDay{Hour: hour, Minute: minute}
+}
+
+// ParseTimeOfDay parses a time of day in "HH:MM" format, e.g. "08:30" or "24:00"
+func ParseTimeOfDay(value string) (TimeOfDay, error) {
+	parts := strings.Split(value, ":")
+	if len(parts) != 2 || len(parts[0]) == 0 || len(parts[0]) > 2 || len(parts[1]) != 2 {
+		return TimeOfDay{}, fmt.Errorf("invalid time of day %q: expected HH:MM", value)
+	}
+	// strconv.Atoi accepts a sign, as in "+8:30" or "-0:30", so check for digits first
+	if !isDigits(parts[0]) || !isDigits(parts[1]) {
+		return TimeOfDay{}, fmt.Errorf("invalid time of day %q: expected HH:MM", value)
+	}
+
+	hour, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return TimeOfDay{}, fmt.Errorf("invalid time of day %q: expected HH:MM", value)
+	}
+	minute, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return TimeOfDay{}, fmt.Errorf("invalid time of day %q: expected HH:MM", value)
+	}
+
+	t := NewTimeOfDay(hour, minute)
+	if !t.isValid() {
+		return TimeOfDay{}, fmt.Errorf("invalid time of day %q: must be between 00:00 and 24:00", value)
+	}
+	return t, nil
+}
+
+// isDigits reports whether value is made up only of the ASCII digits 0 to 9
+func isDigits(value string) bool {
+	for _, digit := range value {
+		if digit < '0' || digit > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// String formats the time of day as "HH:MM"
+func (t TimeOfDay) String() string {
+	return fmt.Sprintf("%02d:%02d", t.Hour, t.Minute)
+}
+
+// Before reports whether t is earlier in the day than other
+func (t TimeOfDay) Before(other TimeOfDay) bool {
+	return t.minutes() < other.minutes()
+}
+
+// String formats the business hours as "HH:MM-HH:MM"
+func (h BusinessHours) String() string {
+	return h.Start.String() + "-" + h.End.String()
+}
+
+// validate checks the window has a valid opening and closing time
+func (h BusinessHours) validate() error {
+	if !h.Start.isValid() || h.Start.Hour == 24 {
+		return fmt.Errorf("%w: start time %v must be between 00:00 and 23:59", ErrInvalidBusinessHours, h.Start)
+	}
+	if !h.End.isValid() || h.End == h.Start || (h.overnight() && h.End.Hour == 24) {
+		return fmt.Errorf("%w: end time %v must differ from start time and be no later than 24:00", ErrInvalidBusinessHours, h.End)
+	}
+	return nil
+}
+
+// overnight reports whether the window crosses midnight, closing on the day after it opens
+func (h BusinessHours) overnight() bool {
+	return !h.Start.Before(h.End)
+}
+
+// closingMinutes returns the closing time in minutes since midnight of the day the window opens
+func (h BusinessHours) closingMinutes() int {
+	if h.overnight() {
+		return h.End.minutes() + 24*60
+	}
+	return h.End.minutes()
+}
+
+// isValid checks the time of day lies between 00:00 and 24:00 inclusive
+func (t TimeOfDay) isValid() bool {
+	if t.Hour < 0 || t.Hour > 24 || t.Minute < 0 || t.Minute >= 60 {
+		return false
+	}
+	return t.Hour < 24 || t.Minute == 0
+}
+
+// minutes returns the number of minutes since midnight
+func (t TimeOfDay) minutes() int {
+	return t.Hour*60 + t.Minute
+}
+
+// on returns the instant at this time of day on the given calendar date.
+// Day overflow is normalised by time.Date, so 24:00 resolves to midnight of the following day.
+// A time skipped by a daylight saving transition resolves to the same distance after the transition,
+// like 01:30 becoming 02:30 when clocks go forward at 01:00, even in zones where time.Date would move
+// it back to the evening before.
+func (t TimeOfDay) on(year int, month time.Month, day int, loc *time.Location) time.Time {
+	instant := time.Date(year, month, day, t.Hour, t.Minute, 0, 0, loc)
+
+	wallClock := time.Date(instant.Year(), instant.Month(), instant.Day(), instant.Hour(), instant.Minute(), 0, 0, time.UTC)
+	if skipped := time.Date(year, month, day, t.Hour, t.Minute, 0, 0, time.UTC).Sub(wallClock); skipped > 0 {
+		return instant.Add(skipped)
+	}
+	return instant
+}
@@ -0,0 +1,57 @@
+package slachecker
+
+import (
+	"testing"
+)
+
+func TestParseTimeOfDay(t *testing.T) {
+	tests := []struct {
+		value       string
+		expected    TimeOfDay
+		expectError bool
+	}{
+		{value: "08:30", expected: NewTimeOfDay(8, 30)},
+		{value: "8:30", expected: NewTimeOfDay(8, 30)},
+		{value: "00:00", expected: NewTimeOfDay(0, 0)},
+		{value: "17:45", expected: NewTimeOfDay(17, 45)},
+		{value: "24:00", expected: NewTimeOfDay(24, 0)},
+		{value: "24:30", expectError: true},
+		{value: "12:60", expectError: true},
+		{value: "12:5", expectError: true},
+		{value: "-1:00", expectError: true},
+		{value: "+8:30", expectError: true},
+		{value: "-0:30", expectError: true},
+		{value: "+9:+5", expectError: true},
+		{value: "08:-0", expectError: true},
+		{value: "٠٨:٣٠", expectError: true},
+		{value: "0830", expectError: true},
+		{value: "08:30:00", expectError: true},
+		{value: "", expectError: true},
+	}
+
+	for _, test := range tests {
+		result, err := ParseTimeOfDay(test.value)
+		if test.expectError {
+			if err == nil {
+				t.Errorf("Expected an error parsing %q, but got %v", test.value, result)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Unexpected error parsing %q: %v", test.value, err)
+			continue
+		}
+		if result != test.expected {
+			t.Errorf("Expected %q to parse as %v, but got %v", test.value, test.expected, result)
+		}
+	}
+}
+
+func TestTimeOfDayString(t *testing.T) {
+	if result := NewTimeOfDay(8, 5).String(); result != "08:05" {
+		t.Errorf("Expected 08:05, but got %s", result)
+	}
+	if result := NewTimeOfDay(24, 0).String(); result != "24:00" {
+		t.Errorf("Expected 24:00, but got %s", result)
+	}
+}